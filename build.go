@@ -0,0 +1,166 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+// PackageStatus is the build status of a package in a repository.
+// For multibuild flavours, Package is pkg:flavour.
+type PackageStatus struct {
+	Package string `xml:"package,attr"      json:"package"`
+	Code    string `xml:"code,attr"         json:"code"`
+	Details string `xml:"details,omitempty" json:"details,omitempty"`
+}
+
+// Flavour returns the name of the package without the multibuild flavour
+// and the flavour, which is empty for the main package.
+func (s *PackageStatus) Flavour() (string, string) {
+	return SplitFlavour(s.Package)
+}
+
+// BuildResult is the state of a repository for an architecture along
+// with the build status of its packages.
+type BuildResult struct {
+	Project    string          `xml:"project,attr"         json:"project"`
+	Repository string          `xml:"repository,attr"      json:"repository"`
+	Arch       string          `xml:"arch,attr"            json:"arch"`
+	Code       string          `xml:"code,attr"            json:"code"`
+	State      string          `xml:"state,attr"           json:"state"`
+	Dirty      bool            `xml:"dirty,attr,omitempty" json:"dirty,omitempty"`
+	Statuses   []PackageStatus `xml:"status"               json:"statuses"`
+}
+
+type resultList struct {
+	XMLName xml.Name      `xml:"resultlist"`
+	State   string        `xml:"state,attr"`
+	Results []BuildResult `xml:"result"`
+}
+
+// BuildResultOptions filters the build results.
+// MultiBuild includes multibuild flavours, LocalLink includes
+// packages linking to the requested ones within the project.
+type BuildResultOptions struct {
+	Packages     []string `url:"package,omitempty"`
+	Repositories []string `url:"repository,omitempty"`
+	Archs        []string `url:"arch,omitempty"`
+	MultiBuild   bool     `url:"multibuild,omitempty,int"`
+	LocalLink    bool     `url:"locallink,omitempty,int"`
+	LastBuild    bool     `url:"lastbuild,omitempty,int"`
+}
+
+// BuildLogOptions selects a part of a build log.
+// Without options, the log of the current or the last build is returned.
+type BuildLogOptions struct {
+	NoStream  bool  `url:"nostream,omitempty,int"`
+	LastBuild bool  `url:"last,omitempty,int"`
+	Start     int64 `url:"start,omitempty"`
+	End       int64 `url:"end,omitempty"`
+}
+
+// Binary is a file built by a package.
+type Binary struct {
+	Filename string `xml:"filename,attr" json:"filename"`
+	Size     int64  `xml:"size,attr"     json:"size"`
+	MTime    int64  `xml:"mtime,attr"    json:"mtime"`
+}
+
+type binaryList struct {
+	Binaries []Binary `xml:"binary"`
+}
+
+// FlavourPackage returns the name addressing the multibuild flavour of
+// a package in build-related calls. An empty flavour means the main
+// package.
+func FlavourPackage(pkg, flavour string) string {
+	if flavour == "" {
+		return pkg
+	}
+
+	return pkg + ":" + flavour
+}
+
+// SplitFlavour splits a name of a package possibly including a multibuild
+// flavour into the name of the package and the flavour.
+func SplitFlavour(name string) (string, string) {
+	pkg, flavour, _ := strings.Cut(name, ":")
+	return pkg, flavour
+}
+
+func buildPath(project, repository, arch, pkg string) string {
+	return "/build/" + project + "/" + repository + "/" + arch + "/" + pkg
+}
+
+// GetBuildResults retrieves the build results of a project.
+func (c *Client) GetBuildResults(project string, opt BuildResultOptions) ([]BuildResult, error) {
+	req, err := c.NewRequest(http.MethodGet, "/build/"+project+"/_result", opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results resultList
+	_, err = c.Do(req, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results.Results, nil
+}
+
+// GetBuildLog retrieves the build log of a package, which can be
+// a multibuild flavour (see FlavourPackage).
+func (c *Client) GetBuildLog(project, repository, arch, pkg string, opt BuildLogOptions) (string, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg)+"/_log", opt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var log strings.Builder
+	_, err = c.Do(req, &log)
+	if err != nil {
+		return "", err
+	}
+
+	return log.String(), nil
+}
+
+// ListBinaries gets a list of files built by a package, which can be
+// a multibuild flavour (see FlavourPackage).
+func (c *Client) ListBinaries(project, repository, arch, pkg string) ([]Binary, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list binaryList
+	_, err = c.Do(req, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Binaries, nil
+}
+
+// GetBinary retrieves a file built by a package, which can be
+// a multibuild flavour (see FlavourPackage).
+func (c *Client) GetBinary(project, repository, arch, pkg, filename string) ([]byte, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg)+"/"+filename, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	_, err = c.Do(req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Builds", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("build results including flavours are requested", func() {
+		It("should return the results per repository", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/_result", "arch=x86_64&arch=aarch64&multibuild=1&package=bar"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<resultlist state="c0ffee">
+							<result project="home:foo" repository="Debian_11" arch="x86_64" code="published" state="published">
+								<status package="bar" code="succeeded"/>
+								<status package="bar:test" code="failed">
+									<details>exit code 1</details>
+								</status>
+							</result>
+							<result project="home:foo" repository="Debian_11" arch="aarch64" code="building" state="building" dirty="true">
+								<status package="bar" code="scheduled"/>
+							</result>
+						</resultlist>`),
+				),
+			)
+			rr, err := c.GetBuildResults("home:foo", BuildResultOptions{
+				Packages:   []string{"bar"},
+				Archs:      []string{"x86_64", "aarch64"},
+				MultiBuild: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rr).To(HaveLen(2))
+			Expect(rr[0].Statuses).To(Equal([]PackageStatus{
+				{"bar", "succeeded", ""},
+				{"bar:test", "failed", "exit code 1"},
+			}))
+			pkg, flavour := rr[0].Statuses[1].Flavour()
+			Expect(pkg).To(Equal("bar"))
+			Expect(flavour).To(Equal("test"))
+			Expect(rr[1].Dirty).To(BeTrue())
+		})
+	})
+
+	When("a build log of a flavour is requested", func() {
+		It("should return the log", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/Debian_11/x86_64/bar:test/_log", "last=1&nostream=1"),
+					ghttp.RespondWith(http.StatusOK, "[    1s] building bar\n"),
+				),
+			)
+			log, err := c.GetBuildLog("home:foo", "Debian_11", "x86_64", FlavourPackage("bar", "test"), BuildLogOptions{
+				NoStream:  true,
+				LastBuild: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(log).To(Equal("[    1s] building bar\n"))
+		})
+	})
+
+	When("binaries of a package are listed", func() {
+		It("should return them", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/Debian_11/x86_64/bar"),
+					ghttp.RespondWith(http.StatusOK, `
+						<binarylist>
+							<binary filename="bar_1.0-1_amd64.deb" size="1234" mtime="1651399200"/>
+							<binary filename="_statistics" size="100" mtime="1651399200"/>
+						</binarylist>`),
+				),
+			)
+			bb, err := c.ListBinaries("home:foo", "Debian_11", "x86_64", "bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bb).To(Equal([]Binary{
+				{"bar_1.0-1_amd64.deb", 1234, 1651399200},
+				{"_statistics", 100, 1651399200},
+			}))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"sort"
+)
+
+// Views of build dependency information.
+const (
+	// BuildDepInfoPkgNames lists the packages a package depends on.
+	BuildDepInfoPkgNames = "pkgnames"
+	// BuildDepInfoRevPkgNames lists the packages depending on a package.
+	BuildDepInfoRevPkgNames = "revpkgnames"
+)
+
+// BuildDepPackage describes the build dependencies of a package.
+// Depending on the view requested, Deps are binary packages the package
+// build-depends on, names of packages building them, or names of packages
+// build-depending on the package.
+type BuildDepPackage struct {
+	Name        string   `xml:"name,attr" json:"name"`
+	Source      string   `xml:"source"    json:"source,omitempty"`
+	SubPackages []string `xml:"subpkg"    json:"subpkgs,omitempty"`
+	Deps        []string `xml:"pkgdep"    json:"deps,omitempty"`
+}
+
+// BuildDepCycle is a set of packages depending on each other.
+type BuildDepCycle struct {
+	Packages []string `xml:"package" json:"packages"`
+}
+
+// BuildDepInfo is the build dependency graph of a repository.
+type BuildDepInfo struct {
+	XMLName  xml.Name          `xml:"builddepinfo" json:"-"`
+	Packages []BuildDepPackage `xml:"package"      json:"packages"`
+	Cycles   []BuildDepCycle   `xml:"cycle"        json:"cycles,omitempty"`
+}
+
+// BuildDepInfoOptions limits the information to the given packages and
+// selects the view (see BuildDepInfoPkgNames and BuildDepInfoRevPkgNames).
+type BuildDepInfoOptions struct {
+	Packages []string `url:"package,omitempty"`
+	View     string   `url:"view,omitempty"`
+}
+
+// GetBuildDepInfo retrieves the build dependency information of
+// a repository for an architecture.
+func (c *Client) GetBuildDepInfo(project, repository, arch string, opt BuildDepInfoOptions) (*BuildDepInfo, error) {
+	req, err := c.NewRequest(http.MethodGet, "/build/"+project+"/"+repository+"/"+arch+"/_builddepinfo", opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info BuildDepInfo
+	_, err = c.Do(req, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// ReverseDeps returns, for each package, the packages which depend on it.
+// The information must have been retrieved with the pkgnames view.
+func (info *BuildDepInfo) ReverseDeps() map[string][]string {
+	rdeps := make(map[string][]string)
+	for _, p := range info.Packages {
+		for _, dep := range p.Deps {
+			rdeps[dep] = append(rdeps[dep], p.Name)
+		}
+	}
+
+	return rdeps
+}
+
+// TransitiveRebuilds returns the sorted names of all packages which get
+// rebuilt when the given packages change, directly or through other
+// rebuilt packages, not including the changed packages themselves unless
+// they are in a cycle. The information must have been retrieved with the
+// pkgnames view for the whole repository.
+func (info *BuildDepInfo) TransitiveRebuilds(changed ...string) []string {
+	rdeps := info.ReverseDeps()
+
+	seen := make(map[string]bool)
+	queue := append([]string(nil), changed...)
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		for _, rdep := range rdeps[pkg] {
+			if !seen[rdep] {
+				seen[rdep] = true
+				queue = append(queue, rdep)
+			}
+		}
+	}
+
+	rebuilds := make([]string, 0, len(seen))
+	for pkg := range seen {
+		rebuilds = append(rebuilds, pkg)
+	}
+	sort.Strings(rebuilds)
+
+	return rebuilds
+}
+
+// GetTransitiveRebuilds retrieves the build dependencies of a repository
+// and returns the packages which get rebuilt when the given ones change.
+func (c *Client) GetTransitiveRebuilds(project, repository, arch string, changed ...string) ([]string, error) {
+	info, err := c.GetBuildDepInfo(project, repository, arch, BuildDepInfoOptions{View: BuildDepInfoPkgNames})
+	if err != nil {
+		return nil, err
+	}
+
+	return info.TransitiveRebuilds(changed...), nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Build dependencies", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("build dependencies of packages are requested", func() {
+		It("should return the graph and the cycles", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/Debian:11/main/x86_64/_builddepinfo", "package=gcc&package=glibc&view=revpkgnames"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<builddepinfo>
+							<package name="gcc">
+								<source>gcc</source>
+								<subpkg>gcc</subpkg>
+								<subpkg>libgcc-s1</subpkg>
+								<pkgdep>glibc</pkgdep>
+							</package>
+							<package name="glibc">
+								<source>glibc</source>
+								<subpkg>libc6</subpkg>
+								<pkgdep>gcc</pkgdep>
+							</package>
+							<cycle>
+								<package>gcc</package>
+								<package>glibc</package>
+							</cycle>
+						</builddepinfo>`),
+				),
+			)
+			info, err := c.GetBuildDepInfo("Debian:11", "main", "x86_64", BuildDepInfoOptions{
+				Packages: []string{"gcc", "glibc"},
+				View:     BuildDepInfoRevPkgNames,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Packages[0]).To(Equal(BuildDepPackage{"gcc", "gcc", []string{"gcc", "libgcc-s1"}, []string{"glibc"}}))
+			Expect(info.Cycles).To(Equal([]BuildDepCycle{{[]string{"gcc", "glibc"}}}))
+		})
+	})
+
+	When("packages rebuilt after a change are computed", func() {
+		It("should follow the reverse dependencies transitively", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/Debian_11/x86_64/_builddepinfo", "view=pkgnames"),
+					ghttp.RespondWith(http.StatusOK, `
+						<builddepinfo>
+							<package name="llvm"/>
+							<package name="clang"><pkgdep>llvm</pkgdep></package>
+							<package name="rust"><pkgdep>llvm</pkgdep></package>
+							<package name="ripgrep"><pkgdep>rust</pkgdep></package>
+							<package name="bash"/>
+							<package name="a"><pkgdep>b</pkgdep></package>
+							<package name="b"><pkgdep>a</pkgdep></package>
+						</builddepinfo>`),
+				),
+			)
+			rebuilds, err := c.GetTransitiveRebuilds("home:foo", "Debian_11", "x86_64", "llvm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rebuilds).To(Equal([]string{"clang", "ripgrep", "rust"}))
+		})
+	})
+
+	When("a package in a cycle changes", func() {
+		It("should include the package itself", func() {
+			info := BuildDepInfo{
+				Packages: []BuildDepPackage{
+					{Name: "a", Deps: []string{"b"}},
+					{Name: "b", Deps: []string{"a"}},
+				},
+			}
+			Expect(info.TransitiveRebuilds("a")).To(Equal([]string{"a", "b"}))
+		})
+	})
+})
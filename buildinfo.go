@@ -0,0 +1,136 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// PackageChange is a change of a build dependency which triggered a build.
+type PackageChange struct {
+	Change string `xml:"change,attr" json:"change"`
+	Key    string `xml:"key,attr"    json:"key"`
+}
+
+// BuildReason explains why a package has been built last time.
+type BuildReason struct {
+	XMLName        xml.Name        `xml:"reason"              json:"-"`
+	Explain        string          `xml:"explain"             json:"explain"`
+	Time           int64           `xml:"time"                json:"time"`
+	OldSource      string          `xml:"oldsource,omitempty" json:"oldsource,omitempty"`
+	PackageChanges []PackageChange `xml:"packagechange"       json:"packagechanges,omitempty"`
+}
+
+// BuildDep is a package installed into the build environment.
+// Preinstall packages are unpacked before anything else, VMInstall ones
+// are installed into the VM, RunScripts ones run their scripts on
+// installation; NoInstall packages are only downloaded.
+type BuildDep struct {
+	Name        string `xml:"name,attr"                  json:"name"`
+	Epoch       string `xml:"epoch,attr,omitempty"       json:"epoch,omitempty"`
+	Version     string `xml:"version,attr,omitempty"     json:"version,omitempty"`
+	Release     string `xml:"release,attr,omitempty"     json:"release,omitempty"`
+	Arch        string `xml:"arch,attr,omitempty"        json:"arch,omitempty"`
+	Project     string `xml:"project,attr,omitempty"     json:"project,omitempty"`
+	Repository  string `xml:"repository,attr,omitempty"  json:"repository,omitempty"`
+	Package     string `xml:"package,attr,omitempty"     json:"package,omitempty"`
+	Preinstall  bool   `xml:"preinstall,attr,omitempty"  json:"preinstall,omitempty"`
+	VMInstall   bool   `xml:"vminstall,attr,omitempty"   json:"vminstall,omitempty"`
+	RunScripts  bool   `xml:"runscripts,attr,omitempty"  json:"runscripts,omitempty"`
+	NoInstall   bool   `xml:"noinstall,attr,omitempty"   json:"noinstall,omitempty"`
+	InstallOnly bool   `xml:"installonly,attr,omitempty" json:"installonly,omitempty"`
+	NotMeta     bool   `xml:"notmeta,attr,omitempty"     json:"notmeta,omitempty"`
+}
+
+// BuildInfo describes how a package is built: the recipe, the version
+// and release, and the build dependencies with where to get them from.
+// If the dependencies cannot be resolved, Error tells why.
+type BuildInfo struct {
+	XMLName     xml.Name    `xml:"buildinfo"           json:"-"`
+	Project     string      `xml:"project,attr"        json:"project"`
+	Repository  string      `xml:"repository,attr"     json:"repository"`
+	Package     string      `xml:"package,attr"        json:"package"`
+	DownloadURL string      `xml:"downloadurl,attr"    json:"downloadurl,omitempty"`
+	Arch        string      `xml:"arch"                json:"arch"`
+	HostArch    string      `xml:"hostarch,omitempty"  json:"hostarch,omitempty"`
+	SrcMD5      string      `xml:"srcmd5,omitempty"    json:"srcmd5,omitempty"`
+	VerifyMD5   string      `xml:"verifymd5,omitempty" json:"verifymd5,omitempty"`
+	Rev         string      `xml:"rev,omitempty"       json:"rev,omitempty"`
+	DistURL     string      `xml:"disturl,omitempty"   json:"disturl,omitempty"`
+	Reason      string      `xml:"reason,omitempty"    json:"reason,omitempty"`
+	RecipeFile  string      `xml:"file,omitempty"      json:"file,omitempty"`
+	SpecFile    string      `xml:"specfile,omitempty"  json:"specfile,omitempty"`
+	VersRel     string      `xml:"versrel,omitempty"   json:"versrel,omitempty"`
+	BCnt        int         `xml:"bcnt,omitempty"      json:"bcnt,omitempty"`
+	Release     string      `xml:"release,omitempty"   json:"release,omitempty"`
+	DebugInfo   int         `xml:"debuginfo,omitempty" json:"debuginfo,omitempty"`
+	Error       string      `xml:"error,omitempty"     json:"error,omitempty"`
+	SubPackages []string    `xml:"subpack"             json:"subpacks,omitempty"`
+	BuildDeps   []BuildDep  `xml:"bdep"                json:"bdeps"`
+	Paths       []PathEntry `xml:"path"                json:"paths,omitempty"`
+}
+
+// BuildInfoOptions adds extra packages to the build dependencies.
+type BuildInfoOptions struct {
+	Add []string `url:"add,omitempty"`
+}
+
+// GetBuildReason retrieves the reason of the last build of a package,
+// which can be a multibuild flavour (see FlavourPackage).
+func (c *Client) GetBuildReason(project, repository, arch, pkg string) (*BuildReason, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg)+"/_reason", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason BuildReason
+	_, err = c.Do(req, &reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reason, nil
+}
+
+// GetBuildInfo retrieves the build information of a package, which can be
+// a multibuild flavour (see FlavourPackage).
+func (c *Client) GetBuildInfo(project, repository, arch, pkg string, opt BuildInfoOptions) (*BuildInfo, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg)+"/_buildinfo", opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info BuildInfo
+	_, err = c.Do(req, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// GetLocalBuildInfo resolves the build dependencies of a local recipe
+// (e.g. a spec or a dsc file) the way OBS would for the package.
+// If pkg is empty, the recipe is resolved against the repository only.
+func (c *Client) GetLocalBuildInfo(project, repository, arch, pkg string, recipe []byte, opt BuildInfoOptions) (*BuildInfo, error) {
+	if pkg == "" {
+		pkg = "_repository"
+	}
+
+	req, err := c.NewRequest(http.MethodPost, buildPath(project, repository, arch, pkg)+"/_buildinfo", opt, string(recipe))
+	if err != nil {
+		return nil, err
+	}
+
+	var info BuildInfo
+	_, err = c.Do(req, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const buildInfoXML = `
+	<buildinfo project="home:foo" repository="Debian_11" package="bar">
+		<arch>x86_64</arch>
+		<srcmd5>aaaa</srcmd5>
+		<verifymd5>aaaa</verifymd5>
+		<rev>3</rev>
+		<file>bar.dsc</file>
+		<versrel>1.0-1</versrel>
+		<bcnt>2</bcnt>
+		<release>1.2</release>
+		<debuginfo>0</debuginfo>
+		<subpack>bar</subpack>
+		<subpack>libbar1</subpack>
+		<bdep name="dpkg" preinstall="1" runscripts="1" version="1.20.9" release="0" arch="amd64" project="Debian:11" repository="main"/>
+		<bdep name="debhelper" version="13.3.4" release="0" arch="all" project="Debian:11" repository="main"/>
+		<bdep name="kvm" vminstall="1" notmeta="1"/>
+		<path project="home:foo" repository="Debian_11"/>
+		<path project="Debian:11" repository="main"/>
+	</buildinfo>`
+
+var _ = Describe("Build information", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the build reason is requested", func() {
+		It("should return the reason and the changes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/Debian_11/x86_64/bar/_reason"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<reason>
+							<explain>meta change</explain>
+							<time>1651399200</time>
+							<packagechange change="md5sum" key="libc6"/>
+						</reason>`),
+				),
+			)
+			reason, err := c.GetBuildReason("home:foo", "Debian_11", "x86_64", "bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reason.Explain).To(Equal("meta change"))
+			Expect(reason.Time).To(Equal(int64(1651399200)))
+			Expect(reason.PackageChanges).To(Equal([]PackageChange{{"md5sum", "libc6"}}))
+		})
+	})
+
+	When("the build information is requested", func() {
+		It("should return the build dependencies", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/Debian_11/x86_64/bar/_buildinfo"),
+					ghttp.RespondWith(http.StatusOK, buildInfoXML),
+				),
+			)
+			info, err := c.GetBuildInfo("home:foo", "Debian_11", "x86_64", "bar", BuildInfoOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.VersRel).To(Equal("1.0-1"))
+			Expect(info.Release).To(Equal("1.2"))
+			Expect(info.BCnt).To(Equal(2))
+			Expect(info.RecipeFile).To(Equal("bar.dsc"))
+			Expect(info.SubPackages).To(Equal([]string{"bar", "libbar1"}))
+			Expect(info.BuildDeps).To(HaveLen(3))
+			Expect(info.BuildDeps[0].Preinstall).To(BeTrue())
+			Expect(info.BuildDeps[0].RunScripts).To(BeTrue())
+			Expect(info.BuildDeps[1].Preinstall).To(BeFalse())
+			Expect(info.BuildDeps[2].VMInstall).To(BeTrue())
+			Expect(info.Paths).To(HaveLen(2))
+		})
+	})
+
+	When("the build information of a local recipe is requested", func() {
+		It("should post the recipe", func() {
+			recipe := "Source: bar\nBuild-Depends: debhelper\n"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/build/home:foo/Debian_11/x86_64/_repository/_buildinfo", "add=ccache"),
+					ghttp.VerifyBody([]byte(recipe)),
+					ghttp.RespondWith(http.StatusOK, buildInfoXML),
+				),
+			)
+			info, err := c.GetLocalBuildInfo("home:foo", "Debian_11", "x86_64", "", []byte(recipe), BuildInfoOptions{Add: []string{"ccache"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Package).To(Equal("bar"))
+		})
+	})
+})
@@ -33,6 +33,9 @@ type Client struct {
 	// Username and password used for basic authentication.
 	username, password string
 
+	// Token used for token authentication.
+	token string
+
 	// User agent used when communicating with the OBS API.
 	UserAgent string
 
@@ -44,7 +47,8 @@ type authType int
 
 const (
 	basicAuth authType = iota
-	userAgent          = "go-obs-api/0"
+	tokenAuth
+	userAgent = "go-obs-api/0"
 )
 
 // NewAPI returns a new OBS API client. To use API methods which
@@ -62,6 +66,22 @@ func NewClient(username, password string, options ...ClientOptionFunc) (*Client,
 	return client, nil
 }
 
+// NewTokenClient returns a new OBS API client which authenticates
+// using a personal API token instead of a username and password.
+// Tokens are only accepted by a limited set of endpoints, such as
+// the /trigger ones.
+func NewTokenClient(token string, options ...ClientOptionFunc) (*Client, error) {
+	client, err := newClient(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.authType = tokenAuth
+	client.token = token
+
+	return client, nil
+}
+
 func newClient(options ...ClientOptionFunc) (*Client, error) {
 	c := &Client{UserAgent: userAgent}
 
@@ -168,6 +188,10 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 		if c.username != "" {
 			req.SetBasicAuth(c.username, c.password)
 		}
+	case tokenAuth:
+		if c.token != "" {
+			req.Header.Set("authorization", "Token "+c.token)
+		}
 	}
 
 	resp, err := c.client.Do(req)
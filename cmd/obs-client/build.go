@@ -0,0 +1,47 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andrewshadura/go-obs"
+	"github.com/urfave/cli/v2"
+)
+
+func buildHistoryCmd(c *cli.Context) error {
+	if c.NArg() != 3 {
+		return fmt.Errorf("project, repository and architecture are required")
+	}
+
+	opt := obs.JobHistoryOptions{
+		Packages: c.StringSlice("package"),
+		Code:     c.StringSlice("code"),
+		Limit:    c.Int("limit"),
+	}
+
+	jobs, err := client.GetJobHistory(c.Args().Get(0), c.Args().Get(1), c.Args().Get(2), opt)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve job history: %s", err)
+	}
+
+	if c.Bool("json") {
+		formatOutput(c, jobs)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "STARTED\tPACKAGE\tVERSION\tCODE\tDURATION\tWORKER\tREASON")
+	for _, j := range jobs {
+		started := time.Unix(j.StartTime, 0).UTC().Format("2006-01-02 15:04:05")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", started, j.Package, j.VersRel, j.Code, j.Duration(), j.WorkerID, j.Reason)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/andrewshadura/go-obs"
+	"github.com/urfave/cli/v2"
+)
+
+type flagEntry struct {
+	Flag       obs.FlagType   `json:"flag"`
+	Status     obs.FlagStatus `json:"status"`
+	Repository string         `json:"repository,omitempty"`
+	Arch       string         `json:"arch,omitempty"`
+}
+
+var flagTargetFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "package",
+		Usage: "Operate on package `PACKAGE` instead of the project",
+	},
+	&cli.StringFlag{
+		Name:  "repository",
+		Usage: "Only apply to repository `REPOSITORY`",
+	},
+	&cli.StringFlag{
+		Name:  "arch",
+		Usage: "Only apply to architecture `ARCH`",
+	},
+	&cli.StringFlag{
+		Name:  "comment",
+		Usage: "Explain why with `COMMENT` (required to unlock)",
+	},
+}
+
+// lock locks a project or a package, recording the comment.
+func lock(c *cli.Context, project string) error {
+	if c.String("repository") != "" || c.String("arch") != "" {
+		return fmt.Errorf("the lock flag cannot be set for a repository or architecture")
+	}
+
+	var err error
+	if pkg := c.String("package"); pkg != "" {
+		err = client.LockPackage(project, pkg, c.String("comment"))
+	} else {
+		err = client.LockProject(project, c.String("comment"))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock: %s", err)
+	}
+
+	return nil
+}
+
+// unlock unlocks a project or a package, which cannot be done by
+// changing the lock flag.
+func unlock(c *cli.Context, project string) error {
+	comment := c.String("comment")
+	if comment == "" {
+		return fmt.Errorf("a comment is required to unlock")
+	}
+
+	var err error
+	if pkg := c.String("package"); pkg != "" {
+		err = client.UnlockPackage(project, pkg, comment)
+	} else {
+		err = client.UnlockProject(project, comment)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unlock: %s", err)
+	}
+
+	return nil
+}
+
+func flagsShowCmd(c *cli.Context) error {
+	project := c.Args().First()
+	if project == "" {
+		return fmt.Errorf("project is required")
+	}
+
+	flags, err := client.GetFlags(project, c.String("package"))
+	if err != nil {
+		return fmt.Errorf("failed to retrieve flags: %s", err)
+	}
+
+	var entries []flagEntry
+	for _, t := range obs.FlagTypes {
+		f, ok := flags[t]
+		if !ok {
+			continue
+		}
+		for _, s := range f.Switches {
+			entries = append(entries, flagEntry{t, s.Status(), s.Repository, s.Arch})
+		}
+	}
+
+	if c.Bool("json") {
+		formatOutput(c, entries)
+	} else {
+		for _, e := range entries {
+			fmt.Printf("%s: %s\n", e.Flag, obs.NewFlagSwitch(e.Status, e.Repository, e.Arch))
+		}
+	}
+
+	return nil
+}
+
+func flagsSetCmd(c *cli.Context) error {
+	if c.NArg() != 3 {
+		return fmt.Errorf("project, flag and status are required")
+	}
+
+	project := c.Args().Get(0)
+	flag := obs.FlagType(c.Args().Get(1))
+	status := obs.FlagStatus(c.Args().Get(2))
+
+	if flag == obs.FlagLock && status == obs.FlagEnable {
+		return lock(c, project)
+	}
+	if flag == obs.FlagLock && status == obs.FlagDisable {
+		return unlock(c, project)
+	}
+
+	err := client.SetFlag(project, c.String("package"), flag, status, c.String("repository"), c.String("arch"))
+	if err != nil {
+		return fmt.Errorf("failed to set %s flag: %s", flag, err)
+	}
+
+	return nil
+}
+
+func flagsUnsetCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("project and flag are required")
+	}
+
+	project := c.Args().Get(0)
+	flag := obs.FlagType(c.Args().Get(1))
+
+	if flag == obs.FlagLock {
+		return unlock(c, project)
+	}
+
+	err := client.RemoveFlag(project, c.String("package"), flag, c.String("repository"), c.String("arch"))
+	if err != nil {
+		return fmt.Errorf("failed to unset %s flag: %s", flag, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/andrewshadura/go-obs"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// groupSpec describes the desired state of a group.
+// It matches the JSON output of `group get`, so it can be used to
+// bootstrap a description of existing groups.
+type groupSpec struct {
+	Name        string   `yaml:"name"`
+	Email       string   `yaml:"email"`
+	Maintainers []string `yaml:"maintainers"`
+	Members     []string `yaml:"members"`
+}
+
+type planStep struct {
+	description string
+	apply       func() error
+}
+
+func readGroupSpecs(path string) ([]groupSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// YAML is a superset of JSON, so this handles both
+	var specs []groupSpec
+	err = yaml.UnmarshalStrict(data, &specs)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("group without a name")
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("group %s listed more than once", spec.Name)
+		}
+		seen[spec.Name] = true
+	}
+
+	return specs, nil
+}
+
+func planGroup(spec groupSpec, exists bool) ([]planStep, error) {
+	var steps []planStep
+	name := spec.Name
+
+	current := &obs.Group{ID: name}
+	if exists {
+		var err error
+		current, err = client.GetGroup(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve group %s: %s", name, err)
+		}
+	} else {
+		steps = append(steps, planStep{
+			fmt.Sprintf("create group %s", name),
+			func() error { return client.NewGroup(name) },
+		})
+	}
+
+	if spec.Email != current.Email {
+		email := spec.Email
+		steps = append(steps, planStep{
+			fmt.Sprintf("set email of group %s to '%s'", name, email),
+			func() error { return client.SetGroupEmail(name, email) },
+		})
+	}
+
+	maintainers := diffMaintainers(current, spec.Maintainers)
+	for _, user := range maintainers.Added {
+		user := user
+		steps = append(steps, planStep{
+			fmt.Sprintf("add maintainer %s to group %s", user, name),
+			func() error { return client.AddGroupMaintainer(name, user) },
+		})
+	}
+	for _, user := range maintainers.Removed {
+		user := user
+		steps = append(steps, planStep{
+			fmt.Sprintf("remove maintainer %s from group %s", user, name),
+			func() error { return client.RemoveGroupMaintainer(name, user) },
+		})
+	}
+
+	members := obs.DiffGroupMembers(current, spec.Members)
+	for _, user := range members.Added {
+		user := user
+		steps = append(steps, planStep{
+			fmt.Sprintf("add user %s to group %s", user, name),
+			func() error { return client.AddGroupMember(name, user) },
+		})
+	}
+	for _, user := range members.Removed {
+		user := user
+		steps = append(steps, planStep{
+			fmt.Sprintf("remove user %s from group %s", user, name),
+			func() error { return client.RemoveGroupMember(name, user) },
+		})
+	}
+
+	return steps, nil
+}
+
+// diffMaintainers computes the maintainers to be added to or removed from
+// the group, reusing the member diff logic.
+func diffMaintainers(g *obs.Group, desired []string) *obs.GroupSyncReport {
+	return obs.DiffGroupMembers(&obs.Group{ID: g.ID, Members: g.Maintainers}, desired)
+}
+
+func groupApplyCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("exactly one group description file is required")
+	}
+
+	specs, err := readGroupSpecs(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read group descriptions: %s", err)
+	}
+
+	groups, err := client.ListGroups()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve groups: %s", err)
+	}
+
+	existing := make(map[string]bool)
+	for _, g := range groups {
+		existing[g] = true
+	}
+
+	var plan []planStep
+	listed := make(map[string]bool)
+	for _, spec := range specs {
+		listed[spec.Name] = true
+
+		steps, err := planGroup(spec, existing[spec.Name])
+		if err != nil {
+			return err
+		}
+		plan = append(plan, steps...)
+	}
+
+	if c.Bool("prune") {
+		for _, g := range groups {
+			if listed[g] {
+				continue
+			}
+			name := g
+			plan = append(plan, planStep{
+				fmt.Sprintf("delete group %s", name),
+				func() error { return deleteGroup(name) },
+			})
+		}
+	}
+
+	for _, step := range plan {
+		fmt.Println(step.description)
+		if c.Bool("dry-run") {
+			continue
+		}
+
+		err = step.apply()
+		if err != nil {
+			return fmt.Errorf("failed to %s: %s", step.description, err)
+		}
+	}
+
+	return nil
+}
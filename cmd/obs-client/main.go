@@ -143,8 +143,10 @@ func groupNewCmd(c *cli.Context) error {
 	return nil
 }
 
-func groupDeleteCmd(c *cli.Context) error {
-	group, err := client.GetGroup(c.Args().First())
+// deleteGroup removes all users from a group and then deletes it,
+// since some OBS versions refuse to delete non-empty groups.
+func deleteGroup(name string) error {
+	group, err := client.GetGroup(name)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve group: %s", err)
 	}
@@ -156,7 +158,7 @@ func groupDeleteCmd(c *cli.Context) error {
 		return fmt.Errorf("failed to remove users from group: %s", err)
 	}
 
-	err = client.DeleteGroup(c.Args().First())
+	err = client.DeleteGroup(name)
 	if err != nil {
 		return fmt.Errorf("failed to delete group: %s", err)
 	}
@@ -164,6 +166,10 @@ func groupDeleteCmd(c *cli.Context) error {
 	return nil
 }
 
+func groupDeleteCmd(c *cli.Context) error {
+	return deleteGroup(c.Args().First())
+}
+
 func groupAddCmd(c *cli.Context) error {
 	err := client.AddGroupMember(c.Args().Get(1), c.Args().Get(0))
 	if err != nil {
@@ -300,6 +306,169 @@ func main() {
 						Usage:  "Remove a user from a group",
 						Action: groupRemoveCmd,
 					},
+					{
+						Name:      "apply",
+						Usage:     "Reconcile groups with a YAML or JSON description",
+						Action:    groupApplyCmd,
+						ArgsUsage: "FILE",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Only print the changes to be made",
+							},
+							&cli.BoolFlag{
+								Name:  "prune",
+								Usage: "Delete groups not listed in FILE",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "source",
+				Usage: "Inspect package sources",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "diff",
+						Usage:     "Show the changes between revisions of a package",
+						Action:    sourceDiffCmd,
+						ArgsUsage: "PROJECT PACKAGE",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "rev",
+								Usage: "Revision `REV` to compare",
+							},
+							&cli.StringFlag{
+								Name:  "oproject",
+								Usage: "Compare against project `PROJECT`",
+							},
+							&cli.StringFlag{
+								Name:  "opackage",
+								Usage: "Compare against package `PACKAGE`",
+							},
+							&cli.StringFlag{
+								Name:  "orev",
+								Usage: "Compare against revision `REV`",
+							},
+							&cli.StringFlag{
+								Name:  "linkrev",
+								Usage: "Expand the link against revision `REV` of the link target",
+							},
+							&cli.BoolFlag{
+								Name:  "unified",
+								Usage: "Produce a single unified diff",
+							},
+							&cli.BoolFlag{
+								Name:  "expand",
+								Usage: "Expand links before comparing",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "build",
+				Usage: "Inspect builds",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "history",
+						Usage:     "Show the history of build jobs of a repository",
+						Action:    buildHistoryCmd,
+						ArgsUsage: "PROJECT REPOSITORY ARCH",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "package",
+								Usage: "Only show jobs of package `PACKAGE`",
+							},
+							&cli.StringSliceFlag{
+								Name:  "code",
+								Usage: "Only show jobs which finished with `CODE` (e.g. succeeded, failed)",
+							},
+							&cli.IntFlag{
+								Name:  "limit",
+								Usage: "Show at most `N` jobs",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "status",
+				Usage: "Show the state of the OBS instance",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "workers",
+						Usage:  "Show the state of the build workers and schedulers",
+						Action: statusWorkersCmd,
+					},
+					{
+						Name:  "messages",
+						Usage: "Manipulate site-wide status messages",
+						Subcommands: []*cli.Command{
+							{
+								Name:   "list",
+								Usage:  "List current status messages",
+								Action: statusMessagesListCmd,
+								Flags: []cli.Flag{
+									&cli.IntFlag{
+										Name:  "limit",
+										Usage: "Show at most `N` messages",
+									},
+								},
+							},
+							{
+								Name:      "create",
+								Usage:     "Post a new status message",
+								Action:    statusMessagesCreateCmd,
+								ArgsUsage: "MESSAGE",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:  "severity",
+										Value: obs.SeverityInformation,
+										Usage: "Severity of the message (information, green, yellow, red, announcement)",
+									},
+									&cli.StringFlag{
+										Name:  "scope",
+										Value: obs.ScopeAllUsers,
+										Usage: "Who sees the message (all_users, logged_in_users, admin_users, in_rollout_users)",
+									},
+								},
+							},
+							{
+								Name:      "delete",
+								Usage:     "Delete a status message",
+								Action:    statusMessagesDeleteCmd,
+								ArgsUsage: "ID",
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:  "flags",
+				Usage: "Manipulate build, publish and other flags",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "show",
+						Usage:     "Show flags of a project or a package",
+						Action:    flagsShowCmd,
+						ArgsUsage: "PROJECT",
+						Flags:     flagTargetFlags[:1],
+					},
+					{
+						Name:      "set",
+						Usage:     "Enable or disable a flag",
+						Action:    flagsSetCmd,
+						ArgsUsage: "PROJECT lock|build|publish|debuginfo|useforbuild enable|disable",
+						Flags:     flagTargetFlags,
+					},
+					{
+						Name:      "unset",
+						Usage:     "Remove a flag setting, reverting to the default",
+						Action:    flagsUnsetCmd,
+						ArgsUsage: "PROJECT lock|build|publish|debuginfo|useforbuild",
+						Flags:     flagTargetFlags,
+					},
 				},
 			},
 		},
@@ -0,0 +1,48 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/andrewshadura/go-obs"
+	"github.com/urfave/cli/v2"
+)
+
+func sourceDiffCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return fmt.Errorf("project and package are required")
+	}
+
+	project, pkg := c.Args().Get(0), c.Args().Get(1)
+	opt := obs.SourceDiffOptions{
+		Rev:        c.String("rev"),
+		OldProject: c.String("oproject"),
+		OldPackage: c.String("opackage"),
+		OldRev:     c.String("orev"),
+		LinkRev:    c.String("linkrev"),
+		Unified:    c.Bool("unified"),
+		Expand:     c.Bool("expand"),
+	}
+
+	if c.Bool("json") {
+		diff, err := client.GetStructuredSourceDiff(project, pkg, opt)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve diff: %s", err)
+		}
+
+		formatOutput(c, diff)
+	} else {
+		diff, err := client.GetSourceDiff(project, pkg, opt)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve diff: %s", err)
+		}
+
+		fmt.Print(diff)
+	}
+
+	return nil
+}
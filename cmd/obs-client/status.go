@@ -0,0 +1,141 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andrewshadura/go-obs"
+	"github.com/urfave/cli/v2"
+)
+
+func statusWorkersCmd(c *cli.Context) error {
+	status, err := client.GetWorkerStatus()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve worker status: %s", err)
+	}
+
+	if c.Bool("json") {
+		formatOutput(c, status)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+
+	type archCounts struct{ idle, building, waiting, blocked int }
+	archs := make(map[string]*archCounts)
+	count := func(arch string) *archCounts {
+		if archs[arch] == nil {
+			archs[arch] = &archCounts{}
+		}
+		return archs[arch]
+	}
+
+	for _, worker := range status.Idle {
+		count(worker.HostArch).idle++
+	}
+	for _, worker := range status.Building {
+		count(worker.HostArch).building++
+	}
+	for _, q := range status.Waiting {
+		count(q.Arch).waiting = q.Jobs
+	}
+	for _, q := range status.Blocked {
+		count(q.Arch).blocked = q.Jobs
+	}
+
+	names := make([]string, 0, len(archs))
+	for arch := range archs {
+		names = append(names, arch)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "ARCH\tIDLE\tBUILDING\tWAITING\tBLOCKED")
+	for _, arch := range names {
+		a := archs[arch]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", arch, a.idle, a.building, a.waiting, a.blocked)
+	}
+	fmt.Fprintf(w, "\ndown: %d, dead: %d, away: %d\n\n", len(status.Down), len(status.Dead), len(status.Away))
+
+	fmt.Fprintln(w, "DAEMON\tARCH\tSTATE\tQUEUE (HIGH/MED/LOW/NEXT)")
+	for _, p := range status.Partitions {
+		for _, d := range p.Daemons {
+			queue := ""
+			if d.Queue != nil {
+				queue = fmt.Sprintf("%d/%d/%d/%d", d.Queue.High, d.Queue.Med, d.Queue.Low, d.Queue.Next)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Type, d.Arch, d.State, queue)
+		}
+	}
+
+	if len(status.Building) > 0 {
+		fmt.Fprintln(w, "\nWORKER\tJOB\tRUNNING FOR")
+		now := time.Now()
+		for _, b := range status.Building {
+			job := fmt.Sprintf("%s/%s/%s/%s", b.Project, b.Repository, b.Arch, b.Package)
+			running := now.Sub(time.Unix(b.StartTime, 0)).Truncate(time.Second)
+			fmt.Fprintf(w, "%s\t%s\t%s\n", b.WorkerID, job, running)
+		}
+	}
+
+	return w.Flush()
+}
+
+func statusMessagesListCmd(c *cli.Context) error {
+	messages, err := client.ListStatusMessages(c.Int("limit"))
+	if err != nil {
+		return fmt.Errorf("failed to retrieve status messages: %s", err)
+	}
+
+	if c.Bool("json") {
+		formatOutput(c, messages)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED\tUSER\tSEVERITY\tSCOPE\tMESSAGE")
+	for _, m := range messages {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", m.ID, m.CreatedAt, m.User, m.Severity, m.Scope, m.Message)
+	}
+
+	return w.Flush()
+}
+
+func statusMessagesCreateCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return fmt.Errorf("exactly one message is required")
+	}
+
+	err := client.CreateStatusMessage(&obs.StatusMessage{
+		Message:  c.Args().First(),
+		Severity: c.String("severity"),
+		Scope:    c.String("scope"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to post status message: %s", err)
+	}
+
+	return nil
+}
+
+func statusMessagesDeleteCmd(c *cli.Context) error {
+	id, err := strconv.Atoi(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid message ID '%s'", c.Args().First())
+	}
+
+	err = client.DeleteStatusMessage(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete status message %d: %s", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Comment is a comment on a project, a package or a request.
+// Replies refer to the comment they reply to by Parent.
+type Comment struct {
+	ID     int    `xml:"id,attr"               json:"id"`
+	Who    string `xml:"who,attr"              json:"who"`
+	When   string `xml:"when,attr"             json:"when"`
+	Parent int    `xml:"parent,attr,omitempty" json:"parent,omitempty"`
+	Body   string `xml:",chardata"             json:"body"`
+}
+
+// CommentThread is a comment along with the replies to it.
+type CommentThread struct {
+	Comment
+	Replies []*CommentThread `json:"replies,omitempty"`
+}
+
+type commentList struct {
+	Comments []Comment `xml:"comment"`
+}
+
+type CommentOptions struct {
+	ParentID int `url:"parent_id,omitempty"`
+}
+
+// ThreadComments arranges comments into threads by their parents,
+// keeping the order of the comments. Replies to comments which aren’t
+// in the list are treated as top-level comments.
+func ThreadComments(comments []Comment) []*CommentThread {
+	threads := make(map[int]*CommentThread)
+	for _, comment := range comments {
+		threads[comment.ID] = &CommentThread{Comment: comment}
+	}
+
+	var roots []*CommentThread
+	for _, comment := range comments {
+		thread := threads[comment.ID]
+		if parent, ok := threads[comment.Parent]; ok && comment.Parent != 0 {
+			parent.Replies = append(parent.Replies, thread)
+		} else {
+			roots = append(roots, thread)
+		}
+	}
+
+	return roots
+}
+
+func (c *Client) listComments(path string) ([]Comment, error) {
+	req, err := c.NewRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list commentList
+	_, err = c.Do(req, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Comments, nil
+}
+
+func (c *Client) createComment(path string, body string, parent int) error {
+	req, err := c.NewRequest(http.MethodPost, path, CommentOptions{ParentID: parent}, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListProjectComments retrieves the comments on a project.
+func (c *Client) ListProjectComments(project string) ([]Comment, error) {
+	return c.listComments("/comments/project/" + project)
+}
+
+// ListPackageComments retrieves the comments on a package.
+func (c *Client) ListPackageComments(project, pkg string) ([]Comment, error) {
+	return c.listComments("/comments/package/" + project + "/" + pkg)
+}
+
+// ListRequestComments retrieves the comments on a request.
+func (c *Client) ListRequestComments(id int) ([]Comment, error) {
+	return c.listComments("/comments/request/" + strconv.Itoa(id))
+}
+
+// CreateProjectComment comments on a project.
+// If parent is not zero, the comment is a reply to that comment.
+func (c *Client) CreateProjectComment(project string, body string, parent int) error {
+	return c.createComment("/comments/project/"+project, body, parent)
+}
+
+// CreatePackageComment comments on a package.
+// If parent is not zero, the comment is a reply to that comment.
+func (c *Client) CreatePackageComment(project, pkg string, body string, parent int) error {
+	return c.createComment("/comments/package/"+project+"/"+pkg, body, parent)
+}
+
+// CreateRequestComment comments on a request.
+// If parent is not zero, the comment is a reply to that comment.
+func (c *Client) CreateRequestComment(id int, body string, parent int) error {
+	return c.createComment("/comments/request/"+strconv.Itoa(id), body, parent)
+}
+
+// DeleteComment deletes a comment.
+func (c *Client) DeleteComment(id int) error {
+	req, err := c.NewRequest(http.MethodDelete, "/comment/"+strconv.Itoa(id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
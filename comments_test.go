@@ -0,0 +1,87 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Comments", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("comments on a request are listed and threaded", func() {
+		It("should attach the replies to their parents", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/comments/request/1234"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<comments request="1234">
+							<comment who="ci-bot" when="2022-05-01 10:00:00 UTC" id="1">Build succeeded</comment>
+							<comment who="foo" when="2022-05-01 11:00:00 UTC" id="2" parent="1">Thanks!</comment>
+							<comment who="bar" when="2022-05-01 12:00:00 UTC" id="3">LGTM</comment>
+							<comment who="ci-bot" when="2022-05-01 13:00:00 UTC" id="4" parent="2">You are welcome</comment>
+						</comments>`),
+				),
+			)
+			cc, err := c.ListRequestComments(1234)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cc).To(HaveLen(4))
+			Expect(cc[1]).To(Equal(Comment{2, "foo", "2022-05-01 11:00:00 UTC", 1, "Thanks!"}))
+
+			threads := ThreadComments(cc)
+			Expect(threads).To(HaveLen(2))
+			Expect(threads[0].ID).To(Equal(1))
+			Expect(threads[0].Replies).To(HaveLen(1))
+			Expect(threads[0].Replies[0].ID).To(Equal(2))
+			Expect(threads[0].Replies[0].Replies[0].Body).To(Equal("You are welcome"))
+			Expect(threads[1].ID).To(Equal(3))
+		})
+	})
+
+	When("a reply is posted on a package", func() {
+		It("should pass the parent and the body", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/comments/package/home:foo/bar", "parent_id=3"),
+					ghttp.VerifyBody([]byte("Fixed in rev 5")),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.CreatePackageComment("home:foo", "bar", "Fixed in rev 5", 3)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a comment is deleted", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, "/comment/3"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.DeleteComment(3)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,108 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+const (
+	actionChangeDevel = "change_devel"
+)
+
+type packageMetaCollection struct {
+	Packages []PackageMeta `xml:"package"`
+}
+
+// develPackage returns the package a package is developed in,
+// or nil if it isn’t developed elsewhere.
+func develPackage(meta *PackageMeta) *PackageRef {
+	if meta.Devel == nil || meta.Devel.Project == "" {
+		return nil
+	}
+
+	name := meta.Devel.Package
+	if name == "" {
+		name = meta.Name
+	}
+
+	return &PackageRef{Project: meta.Devel.Project, Name: name}
+}
+
+// GetDevelProject retrieves the package a package is developed in.
+// If the package isn’t developed elsewhere, nil is returned.
+func (c *Client) GetDevelProject(project, pkg string) (*PackageRef, error) {
+	meta, err := c.GetPackageMeta(project, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	return develPackage(meta), nil
+}
+
+// SetDevelProject sets the package a package is developed in.
+// An empty develPackage refers to the package of the same name,
+// an empty develProject removes the devel package.
+//
+// Unless the user can modify the package, a change_devel request
+// is needed instead (see CreateChangeDevelRequest).
+func (c *Client) SetDevelProject(project, pkg string, develProject, develPackage string) error {
+	meta, err := c.GetPackageMeta(project, pkg)
+	if err != nil {
+		return err
+	}
+
+	if develProject == "" {
+		meta.Devel = nil
+	} else {
+		if develPackage == pkg {
+			develPackage = ""
+		}
+		meta.Devel = &DevelRef{Project: develProject, Package: develPackage}
+	}
+
+	return c.SetPackageMeta(meta)
+}
+
+// ListDevelPackages reports where the packages of a project, usually
+// a distribution, are developed. Packages not developed elsewhere are
+// left out.
+func (c *Client) ListDevelPackages(project string) (map[string]PackageRef, error) {
+	match := XPathAttrEquals("project", project).String()
+	req, err := c.NewRequest(http.MethodGet, "/search/package", SearchOptions{Match: match}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results packageMetaCollection
+	_, err = c.Do(req, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	devel := make(map[string]PackageRef)
+	for i := range results.Packages {
+		ref := develPackage(&results.Packages[i])
+		if ref != nil {
+			devel[results.Packages[i].Name] = *ref
+		}
+	}
+
+	return devel, nil
+}
+
+// CreateChangeDevelRequest requests the package to be developed in
+// develPackage in develProject from now on.
+func (c *Client) CreateChangeDevelRequest(project, pkg string, develProject, develPackage string, description string) (*Request, error) {
+	return c.CreateRequest(&Request{
+		Actions: []RequestAction{{
+			Type:   actionChangeDevel,
+			Source: &RequestSource{Project: develProject, Package: develPackage},
+			Target: &RequestTarget{Project: project, Package: pkg},
+		}},
+		Description: description,
+	})
+}
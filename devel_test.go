@@ -0,0 +1,129 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Devel packages", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the devel project of a package is requested", func() {
+		It("should default to the package of the same name", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/Distro:Factory/bar/_meta"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<package name="bar" project="Distro:Factory">
+							<title>Bar</title>
+							<description/>
+							<devel project="devel:bar"/>
+						</package>`),
+				),
+			)
+			d, err := c.GetDevelProject("Distro:Factory", "bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d).To(Equal(&PackageRef{"devel:bar", "bar"}))
+		})
+	})
+
+	When("the devel project of a package is set", func() {
+		It("should update the meta and keep the rest", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/Distro:Factory/bar/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<package name="bar" project="Distro:Factory">
+							<title>Bar</title>
+							<description/>
+							<person userid="foo" role="maintainer"/>
+						</package>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/Distro:Factory/bar/_meta"),
+					ghttp.VerifyBody([]byte(`<package name="bar" project="Distro:Factory"><title>Bar</title><description></description><devel project="devel:baz" package="bar-ng"></devel><person userid="foo" role="maintainer"></person></package>`)),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.SetDevelProject("Distro:Factory", "bar", "devel:baz", "bar-ng")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("devel packages of a distribution are listed", func() {
+		It("should report those developed elsewhere", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/search/package", "match=%40project%3D%27Distro%3AFactory%27"),
+					ghttp.RespondWith(http.StatusOK, `
+						<collection matches="3">
+							<package name="bar" project="Distro:Factory">
+								<title>Bar</title>
+								<description/>
+								<devel project="devel:bar"/>
+							</package>
+							<package name="baz" project="Distro:Factory">
+								<title>Baz</title>
+								<description/>
+								<devel project="devel:languages" package="baz-devel"/>
+							</package>
+							<package name="quux" project="Distro:Factory">
+								<title>Quux</title>
+								<description/>
+							</package>
+						</collection>`),
+				),
+			)
+			devel, err := c.ListDevelPackages("Distro:Factory")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devel).To(Equal(map[string]PackageRef{
+				"bar": {"devel:bar", "bar"},
+				"baz": {"devel:languages", "baz-devel"},
+			}))
+		})
+	})
+
+	When("a change_devel request is created", func() {
+		It("should return the request and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/request", "cmd=create"),
+					ghttp.VerifyBody([]byte(`<request><action type="change_devel"><source project="devel:baz" package="bar"></source><target project="Distro:Factory" package="bar"></target></action><description>Moving to devel:baz</description></request>`)),
+					ghttp.RespondWith(http.StatusOK, `
+						<request id="1240" creator="foo">
+							<action type="change_devel">
+								<source project="devel:baz" package="bar"/>
+								<target project="Distro:Factory" package="bar"/>
+							</action>
+							<state name="new"/>
+							<description>Moving to devel:baz</description>
+						</request>`),
+				),
+			)
+			r, err := c.CreateChangeDevelRequest("Distro:Factory", "bar", "devel:baz", "bar", "Moving to devel:baz")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.ID).To(Equal(1240))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+)
+
+// DistributionIcon is an icon representing a distribution.
+type DistributionIcon struct {
+	URL    string `xml:"url,attr"              json:"url"`
+	Width  int    `xml:"width,attr,omitempty"  json:"width,omitempty"`
+	Height int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+}
+
+// Distribution represents a distribution projects can be built for.
+// Project and Repository refer to the repository to build against,
+// RepoName is the name usually given to a repository building for it.
+type Distribution struct {
+	XMLName       xml.Name           `xml:"distribution"   json:"-"`
+	ID            string             `xml:"id,attr"        json:"id,omitempty"`
+	Vendor        string             `xml:"vendor,attr"    json:"vendor"`
+	Version       string             `xml:"version,attr"   json:"version"`
+	Name          string             `xml:"name"           json:"name"`
+	Project       string             `xml:"project"        json:"project"`
+	RepoName      string             `xml:"reponame"       json:"reponame"`
+	Repository    string             `xml:"repository"     json:"repository"`
+	Link          string             `xml:"link,omitempty" json:"link,omitempty"`
+	Icons         []DistributionIcon `xml:"icon"           json:"icons,omitempty"`
+	Architectures []string           `xml:"architecture"   json:"architectures"`
+}
+
+type distributions struct {
+	Distributions []Distribution `xml:"distribution"`
+}
+
+// ListDistributions gets a list of distributions the OBS instance
+// offers to build for. If includeRemotes is set, distributions of
+// the instances linked to this one are also included.
+func (c *Client) ListDistributions(includeRemotes bool) ([]Distribution, error) {
+	path := "/distributions"
+	if includeRemotes {
+		path += "/include_remotes"
+	}
+
+	req, err := c.NewRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dists distributions
+	_, err = c.Do(req, &dists)
+	if err != nil {
+		return nil, err
+	}
+
+	return dists.Distributions, nil
+}
+
+// ListArchitectures gets a list of names of all architectures known
+// to the OBS instance.
+func (c *Client) ListArchitectures() ([]string, error) {
+	req, err := c.NewRequest(http.MethodGet, "/architecture", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dir directory
+	_, err = c.Do(req, &dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var archs []string
+	for _, a := range dir.Entries {
+		archs = append(archs, a.Name)
+	}
+
+	return archs, nil
+}
+
+// AddRepositoryFromDistribution adds a repository building against
+// the distribution to the project. The repository is named after the
+// distribution’s RepoName and builds for all of its architectures.
+func (c *Client) AddRepositoryFromDistribution(project string, d *Distribution) error {
+	meta, err := c.GetProjectMeta(project)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range meta.Repositories {
+		if r.Name == d.RepoName {
+			return fmt.Errorf("repository %s already exists in project %s", d.RepoName, project)
+		}
+	}
+
+	meta.Repositories = append(meta.Repositories, Repository{
+		Name: d.RepoName,
+		Paths: []PathEntry{{
+			Project:    d.Project,
+			Repository: d.Repository,
+		}},
+		Architectures: d.Architectures,
+	})
+
+	return c.SetProjectMeta(meta)
+}
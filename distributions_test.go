@@ -0,0 +1,120 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const distributionsXML = `
+	<distributions>
+		<distribution vendor="Debian" version="11" id="1">
+			<name>Debian 11</name>
+			<project>Debian:11</project>
+			<reponame>Debian_11</reponame>
+			<repository>main</repository>
+			<link>https://www.debian.org/</link>
+			<icon url="https://static.opensuse.org/distribution-icons/debian-8.png" width="8" height="8"/>
+			<architecture>x86_64</architecture>
+			<architecture>aarch64</architecture>
+		</distribution>
+	</distributions>`
+
+var _ = Describe("Distributions", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("distributions including remote ones are listed", func() {
+		It("should return the distributions and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/distributions/include_remotes"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, distributionsXML),
+				),
+			)
+			dd, err := c.ListDistributions(true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dd).To(HaveLen(1))
+			Expect(dd[0].Vendor).To(Equal("Debian"))
+			Expect(dd[0].Version).To(Equal("11"))
+			Expect(dd[0].RepoName).To(Equal("Debian_11"))
+			Expect(dd[0].Icons).To(Equal([]DistributionIcon{{"https://static.opensuse.org/distribution-icons/debian-8.png", 8, 8}}))
+			Expect(dd[0].Architectures).To(Equal([]string{"x86_64", "aarch64"}))
+		})
+	})
+
+	When("architectures are listed", func() {
+		It("should return their names and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/architecture"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory count="2">
+							<entry name="aarch64"/>
+							<entry name="x86_64"/>
+						</directory>`),
+				),
+			)
+			aa, err := c.ListArchitectures()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(aa).To(Equal([]string{"aarch64", "x86_64"}))
+		})
+	})
+
+	When("a repository is added from a distribution", func() {
+		It("should add it to the project meta", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="home:foo">
+							<title>Foo</title>
+							<description/>
+							<person userid="foo" role="maintainer"/>
+						</project>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/home:foo/_meta"),
+					ghttp.VerifyBody([]byte(unindent(`
+						<project name="home:foo">
+							<title>Foo</title>
+							<description></description>
+							<person userid="foo" role="maintainer"></person>
+							<repository name="Debian_11">
+								<path project="Debian:11" repository="main"></path>
+								<arch>x86_64</arch>
+								<arch>aarch64</arch>
+							</repository>
+						</project>`))),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.AddRepositoryFromDistribution("home:foo", &Distribution{
+				Project:       "Debian:11",
+				RepoName:      "Debian_11",
+				Repository:    "main",
+				Architectures: []string{"x86_64", "aarch64"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
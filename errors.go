@@ -10,6 +10,7 @@ package obs
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -54,3 +55,34 @@ func CheckResponse(r *http.Response) error {
 
 	return errorResponse
 }
+
+// isNotFound tells whether the error is an API error reporting that
+// the object requested doesn’t exist.
+func isNotFound(err error) bool {
+	var e *ErrorResponse
+	return errors.As(err, &e) && e.Response != nil && e.Response.StatusCode == http.StatusNotFound
+}
+
+type statusData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// status represents a successful status response, which may carry
+// additional named data, e.g. the ID of a newly created object.
+type status struct {
+	XMLName xml.Name     `xml:"status"`
+	Code    string       `xml:"code,attr"`
+	Summary string       `xml:"summary"`
+	Data    []statusData `xml:"data"`
+}
+
+func (s *status) data(name string) string {
+	for _, d := range s.Data {
+		if d.Name == name {
+			return d.Value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events decodes the events OBS publishes on its message bus.
+//
+// OBS publishes events as JSON payloads with routing keys of the form
+// <prefix>.<object>.<action>, e.g. opensuse.obs.package.build_success.
+// The prefix depends on the instance; the event type is the last two
+// components of the routing key.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Types of the events.
+const (
+	PackageCreate         = "package.create"
+	PackageUpdate         = "package.update"
+	PackageDelete         = "package.delete"
+	PackageUndelete       = "package.undelete"
+	PackageCommit         = "package.commit"
+	PackageBuildSuccess   = "package.build_success"
+	PackageBuildFail      = "package.build_fail"
+	PackageBuildUnchanged = "package.build_unchanged"
+	PackageComment        = "package.comment"
+	ProjectCreate         = "project.create"
+	ProjectUpdate         = "project.update"
+	ProjectDelete         = "project.delete"
+	ProjectUndelete       = "project.undelete"
+	ProjectComment        = "project.comment"
+	RequestCreate         = "request.create"
+	RequestChange         = "request.change"
+	RequestDelete         = "request.delete"
+	RequestStateChange    = "request.state_change"
+	RequestReviewWanted   = "request.review_wanted"
+	RequestComment        = "request.comment"
+	RepoPublished         = "repo.published"
+	RepoBuildStarted      = "repo.build_started"
+	RepoBuildFinished     = "repo.build_finished"
+)
+
+// Event is a decoded event.
+type Event interface {
+	// EventType returns the type of the event, e.g. package.build_success.
+	EventType() string
+	// RoutingKey returns the full routing key the event was received with.
+	RoutingKey() string
+
+	setMeta(routingKey, eventType string)
+}
+
+// Meta holds what is known about an event apart from its payload.
+// It is embedded into all events.
+type Meta struct {
+	routingKey string
+	eventType  string
+}
+
+func (m *Meta) EventType() string {
+	return m.eventType
+}
+
+func (m *Meta) RoutingKey() string {
+	return m.routingKey
+}
+
+func (m *Meta) setMeta(routingKey, eventType string) {
+	m.routingKey = routingKey
+	m.eventType = eventType
+}
+
+// ProjectEvent is sent when a project is created, updated or deleted.
+type ProjectEvent struct {
+	Meta
+	Project string `json:"project"`
+	Sender  string `json:"sender,omitempty"`
+}
+
+// PackageEvent is sent when a package is created, updated or deleted.
+type PackageEvent struct {
+	Meta
+	Project string `json:"project"`
+	Package string `json:"package"`
+	Sender  string `json:"sender,omitempty"`
+}
+
+// CommitEvent is sent when sources of a package are committed.
+type CommitEvent struct {
+	Meta
+	Project   string `json:"project"`
+	Package   string `json:"package"`
+	Sender    string `json:"sender,omitempty"`
+	User      string `json:"user,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	Files     string `json:"files,omitempty"`
+	Rev       string `json:"rev"`
+	RequestID int    `json:"requestid,omitempty"`
+}
+
+// BuildEvent is sent when a package finishes building.
+type BuildEvent struct {
+	Meta
+	Project          string `json:"project"`
+	Package          string `json:"package"`
+	Repository       string `json:"repository"`
+	Arch             string `json:"arch"`
+	Release          string `json:"release,omitempty"`
+	ReadyTime        string `json:"readytime,omitempty"`
+	SrcMD5           string `json:"srcmd5,omitempty"`
+	Rev              string `json:"rev,omitempty"`
+	Reason           string `json:"reason,omitempty"`
+	Bcnt             string `json:"bcnt,omitempty"`
+	VerifyMD5        string `json:"verifymd5,omitempty"`
+	HostArch         string `json:"hostarch,omitempty"`
+	StartTime        string `json:"starttime,omitempty"`
+	EndTime          string `json:"endtime,omitempty"`
+	WorkerID         string `json:"workerid,omitempty"`
+	VersRel          string `json:"versrel,omitempty"`
+	PreviouslyFailed string `json:"previouslyfailed,omitempty"`
+	BuildType        string `json:"buildtype,omitempty"`
+}
+
+// RequestAction is an action of a request as sent in request events.
+type RequestAction struct {
+	ID             int    `json:"action_id,omitempty"`
+	Type           string `json:"type"`
+	SourceProject  string `json:"sourceproject,omitempty"`
+	SourcePackage  string `json:"sourcepackage,omitempty"`
+	SourceRevision string `json:"sourcerevision,omitempty"`
+	TargetProject  string `json:"targetproject,omitempty"`
+	TargetPackage  string `json:"targetpackage,omitempty"`
+	TargetRelease  string `json:"target_releaseproject,omitempty"`
+	PersonName     string `json:"person_name,omitempty"`
+	GroupName      string `json:"group_name,omitempty"`
+	Role           string `json:"role,omitempty"`
+}
+
+// RequestEvent is sent when a request is created or changes, and when
+// a review is wanted.
+type RequestEvent struct {
+	Meta
+	Number      int             `json:"number"`
+	Author      string          `json:"author"`
+	Who         string          `json:"who,omitempty"`
+	When        string          `json:"when,omitempty"`
+	State       string          `json:"state"`
+	OldState    string          `json:"oldstate,omitempty"`
+	Comment     string          `json:"comment,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Namespace   string          `json:"namespace,omitempty"`
+	Actions     []RequestAction `json:"actions,omitempty"`
+	ByUser      string          `json:"by_user,omitempty"`
+	ByGroup     string          `json:"by_group,omitempty"`
+	ByProject   string          `json:"by_project,omitempty"`
+	ByPackage   string          `json:"by_package,omitempty"`
+}
+
+// CommentEvent is sent when a project, a package or a request is
+// commented on.
+type CommentEvent struct {
+	Meta
+	Commenter    string   `json:"commenter"`
+	Commenters   []string `json:"commenters,omitempty"`
+	CommentBody  string   `json:"comment_body"`
+	CommentTitle string   `json:"comment_title,omitempty"`
+	Project      string   `json:"project,omitempty"`
+	Package      string   `json:"package,omitempty"`
+	Number       int      `json:"number,omitempty"`
+}
+
+// RepoEvent is sent when a repository starts or finishes building and
+// when it is published.
+type RepoEvent struct {
+	Meta
+	Project string `json:"project"`
+	Repo    string `json:"repo"`
+	Arch    string `json:"arch,omitempty"`
+	BuildID string `json:"buildid,omitempty"`
+}
+
+// UnknownEvent is an event of a type this package doesn’t know about.
+// Its payload is left undecoded.
+type UnknownEvent struct {
+	Meta
+	Payload json.RawMessage
+}
+
+var eventTypes = map[string]func() Event{
+	PackageCreate:         func() Event { return &PackageEvent{} },
+	PackageUpdate:         func() Event { return &PackageEvent{} },
+	PackageDelete:         func() Event { return &PackageEvent{} },
+	PackageUndelete:       func() Event { return &PackageEvent{} },
+	PackageCommit:         func() Event { return &CommitEvent{} },
+	PackageBuildSuccess:   func() Event { return &BuildEvent{} },
+	PackageBuildFail:      func() Event { return &BuildEvent{} },
+	PackageBuildUnchanged: func() Event { return &BuildEvent{} },
+	PackageComment:        func() Event { return &CommentEvent{} },
+	ProjectCreate:         func() Event { return &ProjectEvent{} },
+	ProjectUpdate:         func() Event { return &ProjectEvent{} },
+	ProjectDelete:         func() Event { return &ProjectEvent{} },
+	ProjectUndelete:       func() Event { return &ProjectEvent{} },
+	ProjectComment:        func() Event { return &CommentEvent{} },
+	RequestCreate:         func() Event { return &RequestEvent{} },
+	RequestChange:         func() Event { return &RequestEvent{} },
+	RequestDelete:         func() Event { return &RequestEvent{} },
+	RequestStateChange:    func() Event { return &RequestEvent{} },
+	RequestReviewWanted:   func() Event { return &RequestEvent{} },
+	RequestComment:        func() Event { return &CommentEvent{} },
+	RepoPublished:         func() Event { return &RepoEvent{} },
+	RepoBuildStarted:      func() Event { return &RepoEvent{} },
+	RepoBuildFinished:     func() Event { return &RepoEvent{} },
+}
+
+// TypeOf returns the event type a routing key refers to.
+func TypeOf(routingKey string) string {
+	parts := strings.Split(routingKey, ".")
+	if len(parts) < 2 {
+		return routingKey
+	}
+	return strings.Join(parts[len(parts)-2:], ".")
+}
+
+// Decode decodes the payload of an event received with the routing key.
+// Events of unknown types are returned as *UnknownEvent.
+func Decode(routingKey string, payload []byte) (Event, error) {
+	eventType := TypeOf(routingKey)
+
+	var event Event
+	if newEvent, ok := eventTypes[eventType]; ok {
+		event = newEvent()
+		err := json.Unmarshal(payload, event)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode %s event: %w", eventType, err)
+		}
+	} else {
+		if !json.Valid(payload) {
+			return nil, fmt.Errorf("cannot decode %s event: invalid JSON", eventType)
+		}
+		event = &UnknownEvent{Payload: json.RawMessage(payload)}
+	}
+	event.setMeta(routingKey, eventType)
+
+	return event, nil
+}
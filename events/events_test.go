@@ -0,0 +1,204 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func recorded(eventType string) []byte {
+	payload, err := os.ReadFile(filepath.Join("testdata", eventType+".json"))
+	Expect(err).ToNot(HaveOccurred())
+	return payload
+}
+
+var _ = Describe("Events", func() {
+	When("a routing key is given", func() {
+		It("should strip the prefix", func() {
+			Expect(TypeOf("opensuse.obs.package.build_success")).To(Equal(PackageBuildSuccess))
+			Expect(TypeOf("repo.published")).To(Equal(RepoPublished))
+			Expect(TypeOf("metrics")).To(Equal("metrics"))
+		})
+	})
+
+	When("a recorded build event is decoded", func() {
+		It("should return a build event", func() {
+			e, err := Decode("opensuse.obs.package.build_success", recorded(PackageBuildSuccess))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(e.EventType()).To(Equal(PackageBuildSuccess))
+			Expect(e.RoutingKey()).To(Equal("opensuse.obs.package.build_success"))
+			Expect(e).To(BeAssignableToTypeOf(&BuildEvent{}))
+			b := e.(*BuildEvent)
+			Expect(b.Project).To(Equal("home:foo"))
+			Expect(b.Package).To(Equal("bar"))
+			Expect(b.Repository).To(Equal("openSUSE_Tumbleweed"))
+			Expect(b.Arch).To(Equal("x86_64"))
+			Expect(b.WorkerID).To(Equal("build12:3"))
+			Expect(b.PreviouslyFailed).To(BeEmpty())
+		})
+	})
+
+	When("a recorded request event is decoded", func() {
+		It("should return a request event with its actions", func() {
+			e, err := Decode("opensuse.obs.request.create", recorded(RequestCreate))
+			Expect(err).ToNot(HaveOccurred())
+			r := e.(*RequestEvent)
+			Expect(r.Number).To(Equal(1234))
+			Expect(r.State).To(Equal("new"))
+			Expect(r.Actions).To(Equal([]RequestAction{{
+				ID:             2345,
+				Type:           "submit",
+				SourceProject:  "home:foo",
+				SourcePackage:  "bar",
+				SourceRevision: "3",
+				TargetProject:  "devel:languages",
+				TargetPackage:  "bar",
+			}}))
+		})
+	})
+
+	When("a recorded commit event is decoded", func() {
+		It("should return a commit event", func() {
+			e, err := Decode("opensuse.obs.package.commit", recorded(PackageCommit))
+			Expect(err).ToNot(HaveOccurred())
+			c := e.(*CommitEvent)
+			Expect(c.Rev).To(Equal("3"))
+			Expect(c.RequestID).To(BeZero())
+			Expect(c.Files).To(ContainSubstring("bar.spec"))
+		})
+	})
+
+	When("an event of an unknown type is decoded", func() {
+		It("should keep the payload", func() {
+			e, err := Decode("opensuse.obs.metrics.update", []byte(`{"foo":1}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(e.EventType()).To(Equal("metrics.update"))
+			Expect(string(e.(*UnknownEvent).Payload)).To(Equal(`{"foo":1}`))
+		})
+	})
+
+	When("a payload is malformed", func() {
+		It("should return an error", func() {
+			_, err := Decode("opensuse.obs.repo.published", []byte(`{"project":`))
+			Expect(err).To(HaveOccurred())
+			_, err = Decode("opensuse.obs.metrics.update", []byte(`{"foo":`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("recorded events are consumed from a local transport", func() {
+		It("should dispatch them by type", func() {
+			t := NewLocalTransport(3)
+			Expect(t.Publish("opensuse.obs.package.build_success", recorded(PackageBuildSuccess))).To(Succeed())
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+			Expect(t.Publish("opensuse.obs.project.create", []byte(`{"project":"home:foo:new","sender":"foo"}`))).To(Succeed())
+			Expect(t.Close()).To(Succeed())
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(MatchError(ErrClosed))
+
+			var built, published, other []string
+			mux := NewMux()
+			mux.Handle(PackageBuildSuccess, func(e Event) error {
+				built = append(built, e.(*BuildEvent).Package)
+				return nil
+			})
+			mux.Handle(RepoPublished, func(e Event) error {
+				published = append(published, e.(*RepoEvent).Repo)
+				return nil
+			})
+			mux.HandleOther(func(e Event) error {
+				other = append(other, e.EventType())
+				return nil
+			})
+
+			err := Consume(context.Background(), t, mux.Dispatch, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(built).To(Equal([]string{"bar"}))
+			Expect(published).To(Equal([]string{"openSUSE_Tumbleweed"}))
+			Expect(other).To(Equal([]string{ProjectCreate}))
+		})
+	})
+
+	When("the transport is closed while a publisher is blocked", func() {
+		It("should wake up the publisher", func() {
+			t := NewLocalTransport(1)
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+
+			published := make(chan error)
+			go func() {
+				published <- t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))
+			}()
+			Consistently(published).ShouldNot(Receive())
+
+			Expect(t.Close()).To(Succeed())
+			Eventually(published).Should(Receive(MatchError(ErrClosed)))
+
+			m, err := t.Receive(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.RoutingKey).To(Equal("opensuse.obs.repo.published"))
+			_, err = t.Receive(context.Background())
+			Expect(err).To(Equal(io.EOF))
+		})
+	})
+
+	When("a handler fails", func() {
+		It("should stop consuming", func() {
+			t := NewLocalTransport(2)
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+
+			failure := errors.New("failure")
+			calls := 0
+			err := Consume(context.Background(), t, func(e Event) error {
+				calls++
+				return failure
+			}, nil)
+			Expect(err).To(MatchError(failure))
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	When("a message cannot be decoded", func() {
+		It("should skip it and keep consuming", func() {
+			t := NewLocalTransport(3)
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+			Expect(t.Publish("opensuse.obs.repo.published", []byte(`{"project":`))).To(Succeed())
+			Expect(t.Publish("opensuse.obs.repo.published", recorded(RepoPublished))).To(Succeed())
+			Expect(t.Close()).To(Succeed())
+
+			calls := 0
+			var bad []*Message
+			err := Consume(context.Background(), t, func(e Event) error {
+				calls++
+				return nil
+			}, func(m *Message, err error) {
+				Expect(err).To(HaveOccurred())
+				bad = append(bad, m)
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(calls).To(Equal(2))
+			Expect(bad).To(HaveLen(1))
+			Expect(bad[0].Body).To(Equal([]byte(`{"project":`)))
+		})
+	})
+
+	When("the context is cancelled", func() {
+		It("should stop waiting for messages", func() {
+			t := NewLocalTransport(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := Consume(ctx, t, func(e Event) error { return nil }, nil)
+			Expect(err).To(MatchError(context.Canceled))
+		})
+	})
+})
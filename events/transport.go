@@ -0,0 +1,171 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrClosed is returned when publishing to a closed transport.
+var ErrClosed = errors.New("transport closed")
+
+// Message is a message as received from the bus.
+type Message struct {
+	RoutingKey string
+	Body       []byte
+}
+
+// Transport delivers messages from the bus. An implementation wraps
+// a connection to the bus, e.g. an AMQP channel bound to the OBS
+// exchange.
+type Transport interface {
+	// Receive blocks until a message is available. It returns io.EOF
+	// when no more messages will be delivered, or the error of the
+	// context if it is done first.
+	Receive(ctx context.Context) (*Message, error)
+	Close() error
+}
+
+// LocalTransport is an in-memory transport, messages published to
+// which are received in the same process. It is mostly useful for
+// testing, e.g. with recorded payloads.
+type LocalTransport struct {
+	messages  chan *Message
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewLocalTransport creates a local transport able to hold up to size
+// messages not yet received.
+func NewLocalTransport(size int) *LocalTransport {
+	return &LocalTransport{
+		messages: make(chan *Message, size),
+		done:     make(chan struct{}),
+	}
+}
+
+// Publish queues a message, blocking while the queue is full.
+// If the transport is closed meanwhile, ErrClosed is returned.
+func (t *LocalTransport) Publish(routingKey string, body []byte) error {
+	select {
+	case <-t.done:
+		return ErrClosed
+	default:
+	}
+
+	select {
+	case t.messages <- &Message{RoutingKey: routingKey, Body: body}:
+		return nil
+	case <-t.done:
+		return ErrClosed
+	}
+}
+
+func (t *LocalTransport) Receive(ctx context.Context) (*Message, error) {
+	select {
+	case m := <-t.messages:
+		return m, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-t.done:
+		select {
+		case m := <-t.messages:
+			return m, nil
+		default:
+			return nil, io.EOF
+		}
+	}
+}
+
+// Close stops accepting messages and wakes up blocked publishers.
+// Messages already queued can still be received.
+func (t *LocalTransport) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.done)
+	})
+
+	return nil
+}
+
+// Handler handles a decoded event.
+type Handler func(Event) error
+
+// Mux dispatches events to the handlers registered for their types.
+type Mux struct {
+	handlers map[string][]Handler
+	fallback Handler
+}
+
+func NewMux() *Mux {
+	return &Mux{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Handle registers a handler for events of the type.
+func (m *Mux) Handle(eventType string, h Handler) {
+	m.handlers[eventType] = append(m.handlers[eventType], h)
+}
+
+// HandleOther registers a handler for events no other handlers are
+// registered for.
+func (m *Mux) HandleOther(h Handler) {
+	m.fallback = h
+}
+
+// Dispatch passes the event to the handlers registered for its type,
+// stopping at the first error.
+func (m *Mux) Dispatch(e Event) error {
+	handlers, ok := m.handlers[e.EventType()]
+	if !ok {
+		if m.fallback != nil {
+			return m.fallback(e)
+		}
+		return nil
+	}
+
+	for _, h := range handlers {
+		err := h(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Consume receives messages from the transport, decodes them and
+// passes the events to the handler until the transport runs out of
+// messages or the context is done. Messages which cannot be decoded
+// are skipped and passed to onDecodeError if it is not nil. An error
+// handling an event stops consuming.
+func Consume(ctx context.Context, t Transport, h Handler, onDecodeError func(*Message, error)) error {
+	for {
+		m, err := t.Receive(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		e, err := Decode(m.RoutingKey, m.Body)
+		if err != nil {
+			if onDecodeError != nil {
+				onDecodeError(m, err)
+			}
+			continue
+		}
+
+		err = h(e)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	commandSetFlag    = "set_flag"
+	commandRemoveFlag = "remove_flag"
+)
+
+// FlagType is the kind of a flag block in project or package meta.
+type FlagType string
+
+const (
+	FlagLock        FlagType = "lock"
+	FlagBuild       FlagType = "build"
+	FlagPublish     FlagType = "publish"
+	FlagDebugInfo   FlagType = "debuginfo"
+	FlagUseForBuild FlagType = "useforbuild"
+)
+
+// FlagTypes lists the flag types which can be managed through this client.
+var FlagTypes = []FlagType{FlagLock, FlagBuild, FlagPublish, FlagDebugInfo, FlagUseForBuild}
+
+// FlagStatus tells whether a flag is enabled or disabled.
+type FlagStatus string
+
+const (
+	FlagEnable  FlagStatus = "enable"
+	FlagDisable FlagStatus = "disable"
+)
+
+// FlagSwitch enables or disables a flag, optionally only for the given
+// repository and/or architecture.
+type FlagSwitch struct {
+	XMLName    xml.Name
+	Repository string `xml:"repository,attr,omitempty"`
+	Arch       string `xml:"arch,attr,omitempty"`
+}
+
+// NewFlagSwitch creates a switch setting a flag to the given status.
+func NewFlagSwitch(status FlagStatus, repository, arch string) FlagSwitch {
+	return FlagSwitch{
+		XMLName:    xml.Name{Local: string(status)},
+		Repository: repository,
+		Arch:       arch,
+	}
+}
+
+// Status returns whether the switch enables or disables the flag.
+func (s FlagSwitch) Status() FlagStatus {
+	return FlagStatus(s.XMLName.Local)
+}
+
+func (s FlagSwitch) String() string {
+	str := string(s.Status())
+	if s.Repository != "" {
+		str += " repository=" + s.Repository
+	}
+	if s.Arch != "" {
+		str += " arch=" + s.Arch
+	}
+
+	return str
+}
+
+// Flags represents a flag block (e.g. <build>) of project or package meta.
+type Flags struct {
+	Switches []FlagSwitch `xml:",any"`
+}
+
+func (f Flags) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(f.Switches) == 0 {
+		return nil
+	} else {
+		type flags Flags
+		return e.EncodeElement(flags(f), start)
+	}
+}
+
+// Set enables or disables the flag for the repository and architecture,
+// replacing any switch for exactly the same repository and architecture.
+// Empty repository or arch means the switch applies to all of them.
+func (f *Flags) Set(status FlagStatus, repository, arch string) {
+	for i, s := range f.Switches {
+		if s.Repository == repository && s.Arch == arch {
+			f.Switches[i] = NewFlagSwitch(status, repository, arch)
+			return
+		}
+	}
+
+	f.Switches = append(f.Switches, NewFlagSwitch(status, repository, arch))
+}
+
+// Remove removes the switch for exactly the repository and architecture
+// and returns whether there was one.
+func (f *Flags) Remove(repository, arch string) bool {
+	for i, s := range f.Switches {
+		if s.Repository == repository && s.Arch == arch {
+			f.Switches = append(f.Switches[:i], f.Switches[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Status returns the status of the flag for the repository and
+// architecture as set by the most specific matching switch: one for both
+// the repository and the architecture wins over one for the repository
+// only, which wins over one for the architecture only, which wins over
+// a switch for everything. If no switch matches, an empty string is
+// returned, and the default of OBS applies.
+func (f *Flags) Status(repository, arch string) FlagStatus {
+	if f == nil {
+		return ""
+	}
+
+	best := -1
+	var status FlagStatus
+	for _, s := range f.Switches {
+		if (s.Repository != "" && s.Repository != repository) || (s.Arch != "" && s.Arch != arch) {
+			continue
+		}
+
+		score := 0
+		if s.Repository != "" {
+			score += 2
+		}
+		if s.Arch != "" {
+			score += 1
+		}
+
+		if score > best {
+			best = score
+			status = s.Status()
+		}
+	}
+
+	return status
+}
+
+// flagsField returns the field of the meta holding the flag block of
+// the given type, or nil if the type isn’t known.
+func flagsField(t FlagType, lock, build, publish, debuginfo, useforbuild **Flags) **Flags {
+	switch t {
+	case FlagLock:
+		return lock
+	case FlagBuild:
+		return build
+	case FlagPublish:
+		return publish
+	case FlagDebugInfo:
+		return debuginfo
+	case FlagUseForBuild:
+		return useforbuild
+	}
+
+	return nil
+}
+
+// Flags returns the flag block of the given type, creating an empty one
+// if the project meta doesn’t have it.
+func (m *ProjectMeta) Flags(t FlagType) *Flags {
+	f := flagsField(t, &m.Lock, &m.Build, &m.Publish, &m.DebugInfo, &m.UseForBuild)
+	if f == nil {
+		return nil
+	}
+
+	if *f == nil {
+		*f = &Flags{}
+	}
+
+	return *f
+}
+
+// Flags returns the flag block of the given type, creating an empty one
+// if the package meta doesn’t have it.
+func (m *PackageMeta) Flags(t FlagType) *Flags {
+	f := flagsField(t, &m.Lock, &m.Build, &m.Publish, &m.DebugInfo, &m.UseForBuild)
+	if f == nil {
+		return nil
+	}
+
+	if *f == nil {
+		*f = &Flags{}
+	}
+
+	return *f
+}
+
+type FlagOptions struct {
+	Command    string     `url:"cmd,omitempty"`
+	Flag       FlagType   `url:"flag,omitempty"`
+	Status     FlagStatus `url:"status,omitempty"`
+	Repository string     `url:"repository,omitempty"`
+	Arch       string     `url:"arch,omitempty"`
+}
+
+func sourcePath(project, pkg string) string {
+	if pkg == "" {
+		return "/source/" + project
+	}
+
+	return "/source/" + project + "/" + pkg
+}
+
+func (c *Client) flagCommand(project, pkg string, opt FlagOptions) error {
+	req, err := c.NewRequest(http.MethodPost, sourcePath(project, pkg), opt, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateFlags applies the change to the flag block in the project meta,
+// or the package meta if pkg is not empty, recording the comment in the
+// history of the meta.
+func (c *Client) updateFlags(project, pkg string, t FlagType, comment string, change func(*Flags)) error {
+	if pkg == "" {
+		meta, err := c.GetProjectMeta(project)
+		if err != nil {
+			return err
+		}
+		change(meta.Flags(t))
+		return c.putProjectMeta(meta, comment)
+	}
+
+	meta, err := c.GetPackageMeta(project, pkg)
+	if err != nil {
+		return err
+	}
+	change(meta.Flags(t))
+	return c.putPackageMeta(meta, comment)
+}
+
+func checkFlagType(t FlagType) error {
+	for _, known := range FlagTypes {
+		if t == known {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown flag type %s", t)
+}
+
+// ErrUnlockWithFlags is returned when trying to disable or remove the
+// lock flag. OBS doesn’t allow the meta of a locked project or package
+// to be changed; use UnlockProject or UnlockPackage instead.
+var ErrUnlockWithFlags = errors.New("the lock flag cannot be disabled or removed, use UnlockProject or UnlockPackage")
+
+// SetFlag enables or disables a flag of a project, or of a package if
+// pkg is not empty, for the repository and architecture; empty
+// repository or arch means all of them.
+// The lock flag is enabled by editing the meta, others are changed with
+// cmd=set_flag. The lock flag applies to all repositories and
+// architectures; disabling it returns ErrUnlockWithFlags.
+func (c *Client) SetFlag(project, pkg string, t FlagType, status FlagStatus, repository, arch string) error {
+	err := checkFlagType(t)
+	if err != nil {
+		return err
+	}
+
+	if status != FlagEnable && status != FlagDisable {
+		return fmt.Errorf("unknown flag status %s", status)
+	}
+
+	if t == FlagLock {
+		if status == FlagDisable {
+			return ErrUnlockWithFlags
+		}
+		if repository != "" || arch != "" {
+			return fmt.Errorf("the lock flag cannot be set for a repository or architecture")
+		}
+		return c.lock(project, pkg, "")
+	}
+
+	return c.flagCommand(project, pkg, FlagOptions{
+		Command:    commandSetFlag,
+		Flag:       t,
+		Status:     status,
+		Repository: repository,
+		Arch:       arch,
+	})
+}
+
+// RemoveFlag removes the switch of a flag of a project, or of a package
+// if pkg is not empty, for exactly the repository and architecture,
+// so that the default or a less specific switch applies.
+// Removing the lock flag returns ErrUnlockWithFlags.
+func (c *Client) RemoveFlag(project, pkg string, t FlagType, repository, arch string) error {
+	err := checkFlagType(t)
+	if err != nil {
+		return err
+	}
+
+	if t == FlagLock {
+		return ErrUnlockWithFlags
+	}
+
+	return c.flagCommand(project, pkg, FlagOptions{
+		Command:    commandRemoveFlag,
+		Flag:       t,
+		Repository: repository,
+		Arch:       arch,
+	})
+}
+
+// GetFlags retrieves all flag blocks of a project, or of a package
+// if pkg is not empty. Flag types without any switches are left out.
+func (c *Client) GetFlags(project, pkg string) (map[FlagType]*Flags, error) {
+	flags := make(map[FlagType]*Flags)
+
+	var get func(FlagType) *Flags
+	if pkg == "" {
+		meta, err := c.GetProjectMeta(project)
+		if err != nil {
+			return nil, err
+		}
+		get = meta.Flags
+	} else {
+		meta, err := c.GetPackageMeta(project, pkg)
+		if err != nil {
+			return nil, err
+		}
+		get = meta.Flags
+	}
+
+	for _, t := range FlagTypes {
+		if f := get(t); len(f.Switches) > 0 {
+			flags[t] = f
+		}
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Marshalling", func() {
+	When("package meta with flags is marshalled", func() {
+		It("should leave out empty flag blocks", func() {
+			meta := PackageMeta{
+				Name:    "bar",
+				Project: "home:foo",
+				Build:   &Flags{},
+				Publish: &Flags{[]FlagSwitch{NewFlagSwitch(FlagDisable, "Debian_11", "")}},
+			}
+			data, err := xml.Marshal(meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(unindent(`
+				<package name="bar" project="home:foo">
+					<title></title>
+					<description></description>
+					<publish><disable repository="Debian_11"></disable></publish>
+				</package>`)))
+		})
+	})
+})
+
+var _ = Describe("Flags", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the status of a flag is computed", func() {
+		It("should use the most specific switch", func() {
+			f := Flags{}
+			Expect(f.Status("Debian_11", "x86_64")).To(BeEmpty())
+			f.Set(FlagDisable, "", "")
+			f.Set(FlagEnable, "", "x86_64")
+			f.Set(FlagDisable, "Debian_11", "")
+			f.Set(FlagEnable, "Debian_11", "aarch64")
+			Expect(f.Status("Debian_12", "i586")).To(Equal(FlagDisable))
+			Expect(f.Status("Debian_12", "x86_64")).To(Equal(FlagEnable))
+			Expect(f.Status("Debian_11", "x86_64")).To(Equal(FlagDisable))
+			Expect(f.Status("Debian_11", "aarch64")).To(Equal(FlagEnable))
+			Expect(f.Remove("Debian_11", "")).To(BeTrue())
+			Expect(f.Remove("Debian_11", "")).To(BeFalse())
+			Expect(f.Status("Debian_11", "x86_64")).To(Equal(FlagEnable))
+		})
+	})
+
+	When("publishing is disabled for a repository", func() {
+		It("should use set_flag and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/home:foo", "cmd=set_flag&flag=publish&repository=Debian_11&status=disable"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.SetFlag("home:foo", "", FlagPublish, FlagDisable, "Debian_11", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a package flag is removed", func() {
+		It("should use remove_flag and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/home:foo/bar", "arch=i586&cmd=remove_flag&flag=build"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.RemoveFlag("home:foo", "bar", FlagBuild, "", "i586")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a package is locked", func() {
+		It("should update the package meta", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/bar/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<package name="bar" project="home:foo">
+							<title>Bar</title>
+							<description/>
+							<build><disable arch="i586"/></build>
+						</package>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/home:foo/bar/_meta"),
+					ghttp.VerifyBody([]byte(unindent(`
+						<package name="bar" project="home:foo">
+							<title>Bar</title>
+							<description></description>
+							<lock><enable></enable></lock>
+							<build><disable arch="i586"></disable></build>
+						</package>`))),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.SetFlag("home:foo", "bar", FlagLock, FlagEnable, "", "")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("an unknown flag is set", func() {
+		It("should return an error", func() {
+			err := c.SetFlag("home:foo", "", FlagType("frobnicate"), FlagEnable, "", "")
+			Expect(err).To(MatchError("unknown flag type frobnicate"))
+		})
+	})
+
+	When("flags of a project are requested", func() {
+		It("should return the non-empty flag blocks", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="home:foo">
+							<title>Foo</title>
+							<description/>
+							<build/>
+							<publish><disable/><enable repository="Debian_11" arch="x86_64"/></publish>
+						</project>`),
+				),
+			)
+			flags, err := c.GetFlags("home:foo", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(flags).To(HaveLen(1))
+			Expect(flags[FlagPublish].Switches).To(Equal([]FlagSwitch{
+				NewFlagSwitch(FlagDisable, "", ""),
+				NewFlagSwitch(FlagEnable, "Debian_11", "x86_64"),
+			}))
+		})
+	})
+})
@@ -11,18 +11,26 @@ import (
 )
 
 const (
-	commandAddUser    = "add_user"
-	commandRemoveUser = "remove_user"
-	commandSetEmail   = "set_email"
+	commandAddUser       = "add_user"
+	commandRemoveUser    = "remove_user"
+	commandSetEmail      = "set_email"
+	commandSetMaintainer = "set_maintainer"
 )
 
 // Group represents a named group of users.
 type Group struct {
-	XMLName    xml.Name  `xml:"group"           json:"-"`
-	ID         string    `xml:"title"           json:"name"`
-	Email      string    `xml:"email,omitempty" json:"email,omitempty"`
-	Maintainer UserRef   `xml:"maintainer"      json:"maintainer"`
-	Members    []UserRef `xml:"person>person"   json:"members"`
+	XMLName     xml.Name  `xml:"group"           json:"-"`
+	ID          string    `xml:"title"           json:"name"`
+	Email       string    `xml:"email,omitempty" json:"email,omitempty"`
+	Maintainers []UserRef `xml:"maintainer"      json:"maintainers,omitempty"`
+	Members     []UserRef `xml:"person>person"   json:"members"`
+}
+
+// GroupSyncReport lists the changes made to the membership of a group.
+type GroupSyncReport struct {
+	Group   string   `json:"group"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
 }
 
 type directoryEntry struct {
@@ -168,3 +176,94 @@ func (c *Client) SetGroupEmail(group string, email string) error {
 
 	return nil
 }
+
+type GroupMaintainerOptions struct {
+	Command    string `url:"cmd"`
+	User       string `url:"userid"`
+	Maintainer bool   `url:"maintainer"`
+}
+
+func (c *Client) setGroupMaintainer(group string, user string, maintainer bool) error {
+	req, err := c.NewRequest(http.MethodPost, "/group/"+group, GroupMaintainerOptions{commandSetMaintainer, user, maintainer}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddGroupMaintainer makes a user a maintainer of a group.
+// Group maintainers can manage the members of the group.
+func (c *Client) AddGroupMaintainer(group string, user string) error {
+	return c.setGroupMaintainer(group, user, true)
+}
+
+// RemoveGroupMaintainer revokes the maintainer role of a user in a group.
+// The user remains a member of the group if they were one.
+func (c *Client) RemoveGroupMaintainer(group string, user string) error {
+	return c.setGroupMaintainer(group, user, false)
+}
+
+// DiffGroupMembers computes the changes needed to make the members of
+// the group match the desired list of users, without applying them.
+func DiffGroupMembers(g *Group, desired []string) *GroupSyncReport {
+	report := GroupSyncReport{Group: g.ID}
+
+	current := make(map[string]bool)
+	for _, m := range g.Members {
+		current[m.ID] = true
+	}
+
+	wanted := make(map[string]bool)
+	for _, user := range desired {
+		if !wanted[user] && !current[user] {
+			report.Added = append(report.Added, user)
+		}
+		wanted[user] = true
+	}
+
+	for _, m := range g.Members {
+		if !wanted[m.ID] {
+			report.Removed = append(report.Removed, m.ID)
+		}
+	}
+
+	return &report
+}
+
+// SyncGroupMembers adds and removes members of the group so that they
+// match the desired list of users. The returned report lists the
+// changes which have been applied; if an error occurs, the report
+// covers the changes made up to that point.
+func (c *Client) SyncGroupMembers(group string, desired []string) (*GroupSyncReport, error) {
+	g, err := c.GetGroup(group)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := DiffGroupMembers(g, desired)
+	report := GroupSyncReport{Group: g.ID}
+
+	for _, user := range plan.Added {
+		err = c.AddGroupMember(group, user)
+		if err != nil {
+			return &report, err
+		}
+		report.Added = append(report.Added, user)
+	}
+
+	for _, user := range plan.Removed {
+		err = c.RemoveGroupMember(group, user)
+		if err != nil {
+			return &report, err
+		}
+		report.Removed = append(report.Removed, user)
+	}
+
+	return &report, nil
+}
@@ -236,4 +236,89 @@ var _ = Describe("Groups", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	When("a maintainer is being added to a group", func() {
+		It("should use the set_maintainer command and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=set_maintainer&maintainer=true&userid=bar-maintainer"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok">
+							<summary>Ok</summary>
+						</status>`),
+				),
+			)
+			err := c.AddGroupMaintainer("foo", "bar-maintainer")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a maintainer is being removed from a group", func() {
+		It("should use the set_maintainer command and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=set_maintainer&maintainer=false&userid=foo-maintainer"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.RemoveGroupMaintainer("foo", "foo-maintainer")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("group members are being synchronised", func() {
+		It("should add and remove members and report the changes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/group/foo"),
+					ghttp.RespondWith(http.StatusOK, `
+						<group>
+							<title>foo</title>
+							<person>
+								<person userid="foo-member" />
+								<person userid="bar-member" />
+							</person>
+						</group>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=add_user&userid=baz-member"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=remove_user&userid=bar-member"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			report, err := c.SyncGroupMembers("foo", []string{"foo-member", "baz-member"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*report).To(Equal(GroupSyncReport{
+				Group:   "foo",
+				Added:   []string{"baz-member"},
+				Removed: []string{"bar-member"},
+			}))
+		})
+
+		It("should report the changes made before an error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/group/foo"),
+					ghttp.RespondWith(http.StatusOK, `<group><title>foo</title><person/></group>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=add_user&userid=foo-member"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/group/foo", "cmd=add_user&userid=no-member"),
+					ghttp.RespondWith(http.StatusNotFound, `
+						<status code="not_found">
+							<summary>Couldn't find User with login = no-member</summary>
+						</status>`),
+				),
+			)
+			report, err := c.SyncGroupMembers("foo", []string{"foo-member", "no-member"})
+			Expect(err).To(HaveOccurred())
+			Expect(report.Added).To(Equal([]string{"foo-member"}))
+		})
+	})
 })
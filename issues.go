@@ -0,0 +1,188 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+// States of issues.
+const (
+	IssueOpen    = "OPEN"
+	IssueClosed  = "CLOSED"
+	IssueUnknown = "UNKNOWN"
+)
+
+// Changes of issues between a package and the package it links to.
+const (
+	IssueAdded   = "added"
+	IssueKept    = "kept"
+	IssueChanged = "changed"
+	IssueDeleted = "deleted"
+)
+
+// IssueTracker is an issue tracker OBS knows about. Issues of the
+// tracker are recognised in changelogs and request descriptions by
+// RegExp; Label and ShowURL turn their names into labels and links,
+// with @@@ replaced with the name.
+type IssueTracker struct {
+	Name        string `xml:"name"         json:"name"`
+	Description string `xml:"description"  json:"description"`
+	Kind        string `xml:"kind"         json:"kind"`
+	URL         string `xml:"url"          json:"url"`
+	ShowURL     string `xml:"show-url"     json:"show_url"`
+	RegExp      string `xml:"regex"        json:"regex"`
+	Label       string `xml:"label"        json:"label"`
+	EnableFetch bool   `xml:"enable-fetch" json:"enable_fetch"`
+}
+
+type issueTrackers struct {
+	IssueTrackers []IssueTracker `xml:"issue-tracker"`
+}
+
+// IssueOwner is the person an issue is assigned to.
+type IssueOwner struct {
+	Login    string `xml:"login,omitempty"    json:"login,omitempty"`
+	Email    string `xml:"email,omitempty"    json:"email,omitempty"`
+	RealName string `xml:"realname,omitempty" json:"realname,omitempty"`
+}
+
+// Issue is an issue in an issue tracker, e.g. a bug or a CVE.
+// When listing issues referenced by a package, Change tells whether
+// the issue has been added, kept, changed or deleted compared to the
+// package it links to.
+type Issue struct {
+	Tracker   string      `xml:"tracker"               json:"tracker"`
+	Name      string      `xml:"name"                  json:"name"`
+	Label     string      `xml:"label,omitempty"       json:"label,omitempty"`
+	URL       string      `xml:"url,omitempty"         json:"url,omitempty"`
+	State     string      `xml:"state,omitempty"       json:"state,omitempty"`
+	Summary   string      `xml:"summary,omitempty"     json:"summary,omitempty"`
+	Owner     *IssueOwner `xml:"owner,omitempty"       json:"owner,omitempty"`
+	Change    string      `xml:"change,attr,omitempty" json:"change,omitempty"`
+	CreatedAt string      `xml:"created_at,omitempty"  json:"created_at,omitempty"`
+	UpdatedAt string      `xml:"updated_at,omitempty"  json:"updated_at,omitempty"`
+}
+
+type issueList struct {
+	Issues []Issue `xml:"issue"`
+}
+
+// IssueOptions filters the issues referenced by a package.
+type IssueOptions struct {
+	States  []string `url:"states,comma,omitempty"`
+	Changes []string `url:"changes,comma,omitempty"`
+	Login   string   `url:"login,omitempty"`
+}
+
+type issueViewOptions struct {
+	View string `url:"view"`
+	IssueOptions
+}
+
+// ListIssueTrackers retrieves the issue trackers OBS knows about.
+func (c *Client) ListIssueTrackers() ([]IssueTracker, error) {
+	req, err := c.NewRequest(http.MethodGet, "/issue_trackers", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var trackers issueTrackers
+	_, err = c.Do(req, &trackers)
+	if err != nil {
+		return nil, err
+	}
+
+	return trackers.IssueTrackers, nil
+}
+
+// GetIssueTracker retrieves an issue tracker by its name.
+func (c *Client) GetIssueTracker(name string) (*IssueTracker, error) {
+	req, err := c.NewRequest(http.MethodGet, "/issue_trackers/"+name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracker IssueTracker
+	_, err = c.Do(req, &tracker)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracker, nil
+}
+
+// GetIssue retrieves an issue of an issue tracker.
+func (c *Client) GetIssue(tracker, name string) (*Issue, error) {
+	req, err := c.NewRequest(http.MethodGet, "/issue_trackers/"+tracker+"/issues/"+name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	_, err = c.Do(req, &issue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// ListPackageIssues retrieves the issues referenced in the sources of
+// a package, e.g. in its changelog.
+func (c *Client) ListPackageIssues(project, pkg string, opt IssueOptions) ([]Issue, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg, issueViewOptions{"issues", opt}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues issueList
+	_, err = c.Do(req, &issues)
+	if err != nil {
+		return nil, err
+	}
+
+	return issues.Issues, nil
+}
+
+// SearchIssues finds issues of a tracker in a given state, e.g. open
+// CVEs. An empty state matches issues in any state.
+func (c *Client) SearchIssues(tracker, state string) ([]Issue, error) {
+	match := XPathAttrEquals("tracker", tracker).String()
+	if state != "" {
+		match += " and " + XPathAttrEquals("state", state).String()
+	}
+	req, err := c.NewRequest(http.MethodGet, "/search/issue", SearchOptions{Match: match}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues issueList
+	_, err = c.Do(req, &issues)
+	if err != nil {
+		return nil, err
+	}
+
+	return issues.Issues, nil
+}
+
+// FindPackagesWithIssue finds the packages referencing an issue.
+func (c *Client) FindPackagesWithIssue(tracker, name string) ([]PackageRef, error) {
+	match := "issue[" + XPathAttrEquals("tracker", tracker).String() +
+		" and " + XPathAttrEquals("name", name).String() + "]"
+	req, err := c.NewRequest(http.MethodGet, "/search/package/id", SearchOptions{Match: match}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results packageCollection
+	_, err = c.Do(req, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results.Packages, nil
+}
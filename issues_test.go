@@ -0,0 +1,168 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Issues", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("issue trackers are listed", func() {
+		It("should return them and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/issue_trackers"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<issue-trackers>
+							<issue-tracker>
+								<name>cve</name>
+								<description>CVE Numbers</description>
+								<kind>cve</kind>
+								<label>@@@</label>
+								<enable-fetch>true</enable-fetch>
+								<regex>(?:cve|CVE)-(\d\d\d\d-\d+)</regex>
+								<url>https://cve.mitre.org/</url>
+								<show-url>https://cve.mitre.org/cgi-bin/cvename.cgi?name=@@@</show-url>
+							</issue-tracker>
+						</issue-trackers>`),
+				),
+			)
+			tt, err := c.ListIssueTrackers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tt).To(Equal([]IssueTracker{{
+				Name:        "cve",
+				Description: "CVE Numbers",
+				Kind:        "cve",
+				URL:         "https://cve.mitre.org/",
+				ShowURL:     "https://cve.mitre.org/cgi-bin/cvename.cgi?name=@@@",
+				RegExp:      `(?:cve|CVE)-(\d\d\d\d-\d+)`,
+				Label:       "@@@",
+				EnableFetch: true,
+			}}))
+		})
+	})
+
+	When("an issue is requested", func() {
+		It("should return the issue with its owner", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/issue_trackers/bnc/issues/1234"),
+					ghttp.RespondWith(http.StatusOK, `
+						<issue>
+							<created_at>2022-05-01 10:00:00 UTC</created_at>
+							<updated_at>2022-05-02 10:00:00 UTC</updated_at>
+							<name>1234</name>
+							<tracker>bnc</tracker>
+							<label>bnc#1234</label>
+							<url>https://bugzilla.suse.com/show_bug.cgi?id=1234</url>
+							<state>OPEN</state>
+							<summary>bar crashes on start</summary>
+							<owner>
+								<login>foo</login>
+								<email>foo@example.com</email>
+								<realname>Foo Bar</realname>
+							</owner>
+						</issue>`),
+				),
+			)
+			i, err := c.GetIssue("bnc", "1234")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*i).To(Equal(Issue{
+				Tracker:   "bnc",
+				Name:      "1234",
+				Label:     "bnc#1234",
+				URL:       "https://bugzilla.suse.com/show_bug.cgi?id=1234",
+				State:     IssueOpen,
+				Summary:   "bar crashes on start",
+				Owner:     &IssueOwner{"foo", "foo@example.com", "Foo Bar"},
+				CreatedAt: "2022-05-01 10:00:00 UTC",
+				UpdatedAt: "2022-05-02 10:00:00 UTC",
+			}))
+		})
+	})
+
+	When("issues of a package are listed", func() {
+		It("should return them with their changes", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/bar", "view=issues&changes=added%2Cchanged"),
+					ghttp.RespondWith(http.StatusOK, `
+						<package project="home:foo" name="bar">
+							<issue change="added">
+								<name>CVE-2022-1234</name>
+								<tracker>cve</tracker>
+								<label>CVE-2022-1234</label>
+								<url>https://cve.mitre.org/cgi-bin/cvename.cgi?name=CVE-2022-1234</url>
+							</issue>
+						</package>`),
+				),
+			)
+			ii, err := c.ListPackageIssues("home:foo", "bar", IssueOptions{Changes: []string{IssueAdded, IssueChanged}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ii).To(HaveLen(1))
+			Expect(ii[0].Name).To(Equal("CVE-2022-1234"))
+			Expect(ii[0].Change).To(Equal(IssueAdded))
+		})
+	})
+
+	When("packages referencing a CVE are searched for", func() {
+		It("should return them and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/search/package/id", "match=issue%5B%40tracker%3D%27cve%27+and+%40name%3D%27CVE-2022-1234%27%5D"),
+					ghttp.RespondWith(http.StatusOK, `
+						<collection matches="2">
+							<package project="home:foo" name="bar"/>
+							<package project="devel:bar" name="bar"/>
+						</collection>`),
+				),
+			)
+			pp, err := c.FindPackagesWithIssue("cve", "CVE-2022-1234")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pp).To(Equal([]PackageRef{{"home:foo", "bar"}, {"devel:bar", "bar"}}))
+		})
+	})
+
+	When("open issues of a tracker are searched for", func() {
+		It("should return them and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/search/issue", "match=%40tracker%3D%27cve%27+and+%40state%3D%27OPEN%27"),
+					ghttp.RespondWith(http.StatusOK, `
+						<collection>
+							<issue>
+								<name>CVE-2022-1234</name>
+								<tracker>cve</tracker>
+								<state>OPEN</state>
+							</issue>
+						</collection>`),
+				),
+			)
+			ii, err := c.SearchIssues("cve", IssueOpen)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ii).To(Equal([]Issue{{Tracker: "cve", Name: "CVE-2022-1234", State: IssueOpen}}))
+		})
+	})
+})
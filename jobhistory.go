@@ -0,0 +1,127 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// JobHistory is a finished build job.
+// Times are Unix timestamps.
+type JobHistory struct {
+	Package   string `xml:"package,attr"   json:"package"`
+	Rev       string `xml:"rev,attr"       json:"rev"`
+	SrcMD5    string `xml:"srcmd5,attr"    json:"srcmd5"`
+	VersRel   string `xml:"versrel,attr"   json:"versrel"`
+	BCnt      int    `xml:"bcnt,attr"      json:"bcnt"`
+	ReadyTime int64  `xml:"readytime,attr" json:"readytime"`
+	StartTime int64  `xml:"starttime,attr" json:"starttime"`
+	EndTime   int64  `xml:"endtime,attr"   json:"endtime"`
+	Code      string `xml:"code,attr"      json:"code"`
+	URI       string `xml:"uri,attr"       json:"uri,omitempty"`
+	WorkerID  string `xml:"workerid,attr"  json:"workerid"`
+	HostArch  string `xml:"hostarch,attr"  json:"hostarch"`
+	Reason    string `xml:"reason,attr"    json:"reason"`
+	VerifyMD5 string `xml:"verifymd5,attr" json:"verifymd5,omitempty"`
+}
+
+// Duration returns how long the build took.
+func (j *JobHistory) Duration() time.Duration {
+	return time.Duration(j.EndTime-j.StartTime) * time.Second
+}
+
+// WaitTime returns how long the job waited for a worker.
+func (j *JobHistory) WaitTime() time.Duration {
+	return time.Duration(j.StartTime-j.ReadyTime) * time.Second
+}
+
+type jobHistoryList struct {
+	XMLName xml.Name     `xml:"jobhistlist"`
+	Jobs    []JobHistory `xml:"jobhist"`
+}
+
+// JobHistoryOptions filters the job history.
+type JobHistoryOptions struct {
+	Packages []string `url:"package,omitempty"`
+	Code     []string `url:"code,omitempty"`
+	Limit    int      `url:"limit,omitempty"`
+}
+
+// StatisticsValue is a measurement along with its unit.
+type StatisticsValue struct {
+	Value int64  `xml:",chardata"           json:"value"`
+	Unit  string `xml:"unit,attr,omitempty" json:"unit,omitempty"`
+}
+
+// ResourceUsage is the disk or memory usage of a build.
+type ResourceUsage struct {
+	Size       *StatisticsValue `xml:"usage>size"                  json:"size,omitempty"`
+	IORequests int64            `xml:"usage>io_requests,omitempty" json:"io_requests,omitempty"`
+	IOSectors  int64            `xml:"usage>io_sectors,omitempty"  json:"io_sectors,omitempty"`
+}
+
+// BuildTimes are the durations of the phases of a build.
+type BuildTimes struct {
+	Total      *StatisticsValue `xml:"total>time"      json:"total,omitempty"`
+	Preinstall *StatisticsValue `xml:"preinstall>time" json:"preinstall,omitempty"`
+	Install    *StatisticsValue `xml:"install>time"    json:"install,omitempty"`
+	Main       *StatisticsValue `xml:"main>time"       json:"main,omitempty"`
+	Download   *StatisticsValue `xml:"download>time"   json:"download,omitempty"`
+}
+
+// BuildDownload describes the dependencies downloaded for a build.
+type BuildDownload struct {
+	Size            *StatisticsValue `xml:"size"                      json:"size,omitempty"`
+	Binaries        int              `xml:"binaries,omitempty"        json:"binaries,omitempty"`
+	CacheHits       int              `xml:"cachehits,omitempty"       json:"cachehits,omitempty"`
+	PreinstallImage string           `xml:"preinstallimage,omitempty" json:"preinstallimage,omitempty"`
+}
+
+// BuildStatistics describes the resources used by the last build
+// of a package.
+type BuildStatistics struct {
+	XMLName  xml.Name       `xml:"buildstatistics"    json:"-"`
+	Disk     *ResourceUsage `xml:"disk,omitempty"     json:"disk,omitempty"`
+	Memory   *ResourceUsage `xml:"memory,omitempty"   json:"memory,omitempty"`
+	Times    *BuildTimes    `xml:"times,omitempty"    json:"times,omitempty"`
+	Download *BuildDownload `xml:"download,omitempty" json:"download,omitempty"`
+}
+
+// GetJobHistory retrieves the history of build jobs of a repository
+// for an architecture, oldest first.
+func (c *Client) GetJobHistory(project, repository, arch string, opt JobHistoryOptions) ([]JobHistory, error) {
+	req, err := c.NewRequest(http.MethodGet, "/build/"+project+"/"+repository+"/"+arch+"/_jobhistory", opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list jobHistoryList
+	_, err = c.Do(req, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Jobs, nil
+}
+
+// GetBuildStatistics retrieves the statistics of the last build of
+// a package, which can be a multibuild flavour (see FlavourPackage).
+func (c *Client) GetBuildStatistics(project, repository, arch, pkg string) (*BuildStatistics, error) {
+	req, err := c.NewRequest(http.MethodGet, buildPath(project, repository, arch, pkg)+"/_statistics", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats BuildStatistics
+	_, err = c.Do(req, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
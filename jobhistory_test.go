@@ -0,0 +1,104 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Job history", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the job history of a package is requested", func() {
+		It("should return the jobs", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/devel:llvm/Debian_11/x86_64/_jobhistory", "code=succeeded&limit=2&package=llvm"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<jobhistlist>
+							<jobhist package="llvm" rev="12" srcmd5="aaaa" versrel="14.0.0-1" bcnt="1" readytime="1651390000" starttime="1651390060" endtime="1651397260" code="succeeded" uri="http://10.0.0.2:4711" workerid="worker:3" hostarch="x86_64" reason="new build" verifymd5="aaaa"/>
+						</jobhistlist>`),
+				),
+			)
+			jobs, err := c.GetJobHistory("devel:llvm", "Debian_11", "x86_64", JobHistoryOptions{
+				Packages: []string{"llvm"},
+				Code:     []string{"succeeded"},
+				Limit:    2,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(1))
+			Expect(jobs[0].WorkerID).To(Equal("worker:3"))
+			Expect(jobs[0].Reason).To(Equal("new build"))
+			Expect(jobs[0].Duration()).To(Equal(2 * time.Hour))
+			Expect(jobs[0].WaitTime()).To(Equal(time.Minute))
+		})
+	})
+
+	When("build statistics of a package are requested", func() {
+		It("should return the resource usage", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/devel:llvm/Debian_11/x86_64/llvm/_statistics"),
+					ghttp.RespondWith(http.StatusOK, `
+						<buildstatistics>
+							<disk>
+								<usage>
+									<size unit="M">15230</size>
+									<io_requests>1500</io_requests>
+									<io_sectors>250000</io_sectors>
+								</usage>
+							</disk>
+							<memory>
+								<usage>
+									<size unit="M">7500</size>
+								</usage>
+							</memory>
+							<times>
+								<total><time unit="s">7200</time></total>
+								<preinstall><time unit="s">10</time></preinstall>
+								<install><time unit="s">40</time></install>
+								<main><time unit="s">7100</time></main>
+								<download><time unit="s">5</time></download>
+							</times>
+							<download>
+								<size unit="k">90000</size>
+								<binaries>120</binaries>
+								<cachehits>100</cachehits>
+								<preinstallimage>preinstallimage-Debian_11.tar.zst</preinstallimage>
+							</download>
+						</buildstatistics>`),
+				),
+			)
+			stats, err := c.GetBuildStatistics("devel:llvm", "Debian_11", "x86_64", "llvm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats.Disk.Size).To(Equal(&StatisticsValue{15230, "M"}))
+			Expect(stats.Disk.IORequests).To(Equal(int64(1500)))
+			Expect(stats.Memory.Size).To(Equal(&StatisticsValue{7500, "M"}))
+			Expect(stats.Times.Total).To(Equal(&StatisticsValue{7200, "s"}))
+			Expect(stats.Times.Main).To(Equal(&StatisticsValue{7100, "s"}))
+			Expect(stats.Download.Binaries).To(Equal(120))
+			Expect(stats.Download.PreinstallImage).To(Equal("preinstallimage-Debian_11.tar.zst"))
+		})
+	})
+})
@@ -0,0 +1,184 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	commandLinkDiff = "linkdiff"
+)
+
+// LinkInfo describes the link of a linked package.
+// SrcMD5 is the revision of the link target the sources are expanded
+// against, BaseRev the one the link was last updated to, XSrcMD5 the
+// expanded sources and LSrcMD5 the unexpanded sources of the link.
+// If the link cannot be expanded, Error tells why.
+type LinkInfo struct {
+	Project string `xml:"project,attr"           json:"project"`
+	Package string `xml:"package,attr"           json:"package"`
+	SrcMD5  string `xml:"srcmd5,attr"            json:"srcmd5"`
+	BaseRev string `xml:"baserev,attr,omitempty" json:"baserev,omitempty"`
+	XSrcMD5 string `xml:"xsrcmd5,attr,omitempty" json:"xsrcmd5,omitempty"`
+	LSrcMD5 string `xml:"lsrcmd5,attr,omitempty" json:"lsrcmd5,omitempty"`
+	Error   string `xml:"error,attr,omitempty"   json:"error,omitempty"`
+}
+
+// IsBroken tells whether the link cannot be expanded.
+func (li *LinkInfo) IsBroken() bool {
+	return li != nil && li.Error != ""
+}
+
+// Link represents the _link file of a linked package.
+// CICount tells how the check-in counter of the link is computed, VRev
+// overrides the version revision and MissingOK allows the link target
+// not to exist. Attributes not modelled here are kept in Attrs, so that
+// they survive modifying the link.
+type Link struct {
+	XMLName   xml.Name    `xml:"link"                     json:"-"`
+	Project   string      `xml:"project,attr,omitempty"   json:"project,omitempty"`
+	Package   string      `xml:"package,attr,omitempty"   json:"package,omitempty"`
+	Rev       string      `xml:"rev,attr,omitempty"       json:"rev,omitempty"`
+	BaseRev   string      `xml:"baserev,attr,omitempty"   json:"baserev,omitempty"`
+	VRev      string      `xml:"vrev,attr,omitempty"      json:"vrev,omitempty"`
+	CICount   string      `xml:"cicount,attr,omitempty"   json:"cicount,omitempty"`
+	MissingOK string      `xml:"missingok,attr,omitempty" json:"missingok,omitempty"`
+	Attrs     []xml.Attr  `xml:",any,attr"                json:"-"`
+	Patches   *rawElement `xml:"patches,omitempty"        json:"-"`
+}
+
+// BrokenLink reports a package whose link cannot be expanded.
+type BrokenLink struct {
+	Package  string    `json:"package"`
+	LinkInfo *LinkInfo `json:"linkinfo"`
+}
+
+// GetLink retrieves the _link file of a package.
+func (c *Client) GetLink(project, pkg string) (*Link, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg+"/_link", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var link Link
+	_, err = c.Do(req, &link)
+	if err != nil {
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// SetLink replaces the _link file of a package.
+func (c *Client) SetLink(project, pkg string, link *Link) error {
+	req, err := c.NewRequest(http.MethodPut, "/source/"+project+"/"+pkg+"/_link", nil, link)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetLinkDiff retrieves the changes the link makes to the sources of the
+// link target as text. Only Rev, LinkRev and Unified of opt are used.
+func (c *Client) GetLinkDiff(project, pkg string, opt SourceDiffOptions) (string, error) {
+	opt = SourceDiffOptions{
+		Command: commandLinkDiff,
+		Rev:     opt.Rev,
+		LinkRev: opt.LinkRev,
+		Unified: opt.Unified,
+	}
+	req, err := c.NewRequest(http.MethodPost, "/source/"+project+"/"+pkg, opt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	_, err = c.Do(req, &diff)
+	if err != nil {
+		return "", err
+	}
+
+	return diff.String(), nil
+}
+
+// FixLink updates the base revision of the link of a package to the
+// current revision of the link target, the way osc does after the
+// changes of the link have been merged into the target.
+// OBS has no server-side command for this, so the _link file is
+// rewritten.
+//
+// A broken link, i.e. one whose patches no longer apply, cannot be
+// fixed this way: its changes have to be merged with the link target
+// first, so an error is returned instead.
+func (c *Client) FixLink(project, pkg string) error {
+	dir, err := c.ListSourceFiles(project, pkg, SourceOptions{})
+	if err != nil {
+		return err
+	}
+
+	if dir.LinkInfo == nil {
+		return fmt.Errorf("package %s/%s is not a link", project, pkg)
+	}
+
+	if dir.LinkInfo.IsBroken() {
+		return fmt.Errorf("link of %s/%s is broken and needs merging: %s", project, pkg, dir.LinkInfo.Error)
+	}
+
+	link, err := c.GetLink(project, pkg)
+	if err != nil {
+		return err
+	}
+
+	if link.BaseRev == dir.LinkInfo.SrcMD5 {
+		return nil
+	}
+
+	link.BaseRev = dir.LinkInfo.SrcMD5
+
+	return c.SetLink(project, pkg, link)
+}
+
+// ListBrokenLinks reports the packages of a project whose links cannot
+// be expanded. The source info of the project tells which packages
+// have problems, only those are then checked in detail. Packages
+// which disappear meanwhile are skipped.
+func (c *Client) ListBrokenLinks(project string) ([]BrokenLink, error) {
+	infos, err := c.GetSourceInfo(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var broken []BrokenLink
+	for _, info := range infos {
+		if info.Error == "" {
+			continue
+		}
+
+		dir, err := c.ListSourceFiles(project, info.Package, SourceOptions{})
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return broken, fmt.Errorf("failed to check package %s: %w", info.Package, err)
+		}
+
+		if dir.LinkInfo.IsBroken() {
+			broken = append(broken, BrokenLink{info.Package, dir.LinkInfo})
+		}
+	}
+
+	return broken, nil
+}
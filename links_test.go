@@ -0,0 +1,139 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Links", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("broken links of a project are listed", func() {
+		It("should only report the packages with broken links", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo", "view=info&nofilename=1"),
+					ghttp.RespondWith(http.StatusOK, `
+						<sourceinfolist>
+							<sourceinfo package="bar" rev="1" srcmd5="aaaa"/>
+							<sourceinfo package="baz" rev="4" srcmd5="dddd">
+								<error>conflict in file baz.spec</error>
+							</sourceinfo>
+							<sourceinfo package="gone" rev="1" srcmd5="9999">
+								<error>conflict in file gone.spec</error>
+							</sourceinfo>
+							<sourceinfo package="qux" rev="2" srcmd5="0000">
+								<error>bad build configuration, no build type defined or detected</error>
+							</sourceinfo>
+						</sourceinfolist>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/baz"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory name="baz" rev="4" srcmd5="dddd">
+							<linkinfo project="devel:baz" package="baz" srcmd5="eeee" baserev="ffff" error="conflict in file baz.spec"/>
+						</directory>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/gone"),
+					ghttp.RespondWith(http.StatusNotFound, `<status code="unknown_package"><summary>gone</summary></status>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/qux"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory name="qux" rev="2" srcmd5="0000">
+							<entry name="qux.spec" md5="1111" size="10" mtime="1651399200"/>
+						</directory>`),
+				),
+			)
+			broken, err := c.ListBrokenLinks("home:foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(broken).To(Equal([]BrokenLink{{
+				Package: "baz",
+				LinkInfo: &LinkInfo{
+					Project: "devel:baz",
+					Package: "baz",
+					SrcMD5:  "eeee",
+					BaseRev: "ffff",
+					Error:   "conflict in file baz.spec",
+				},
+			}}))
+		})
+	})
+
+	When("a link is fixed", func() {
+		It("should update the base revision of the link", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/baz"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory name="baz" rev="4" srcmd5="dddd">
+							<linkinfo project="devel:baz" package="baz" srcmd5="eeee" baserev="ffff"/>
+						</directory>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/baz/_link"),
+					ghttp.RespondWith(http.StatusOK, `<link project="devel:baz" baserev="ffff" cicount="copy" vrev="3" missingok="true" unknown="kept"><patches><branch/></patches></link>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/home:foo/baz/_link"),
+					ghttp.VerifyBody([]byte(`<link project="devel:baz" baserev="eeee" vrev="3" cicount="copy" missingok="true" unknown="kept"><patches><branch/></patches></link>`)),
+					ghttp.RespondWith(http.StatusOK, `<revision rev="5"/>`),
+				),
+			)
+			err := c.FixLink("home:foo", "baz")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a broken link is being fixed", func() {
+		It("should return an error without changing the link", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/baz"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory name="baz" rev="4" srcmd5="dddd">
+							<linkinfo project="devel:baz" package="baz" srcmd5="eeee" baserev="ffff" error="conflict in file baz.spec"/>
+						</directory>`),
+				),
+			)
+			err := c.FixLink("home:foo", "baz")
+			Expect(err).To(MatchError("link of home:foo/baz is broken and needs merging: conflict in file baz.spec"))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	When("a link diff is requested", func() {
+		It("should return the diff as text", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/home:foo/baz", "cmd=linkdiff&linkrev=base"),
+					ghttp.RespondWith(http.StatusOK, "@@ -1 +1 @@\n-a\n+b\n"),
+				),
+			)
+			diff, err := c.GetLinkDiff("home:foo", "baz", SourceDiffOptions{LinkRev: "base", OldRev: "ignored"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(Equal("@@ -1 +1 @@\n-a\n+b\n"))
+		})
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+const (
+	commandUnlock = "unlock"
+)
+
+type LockOptions struct {
+	Command string `url:"cmd"`
+	Comment string `url:"comment,omitempty"`
+}
+
+func (c *Client) lock(project, pkg string, comment string) error {
+	return c.updateFlags(project, pkg, FlagLock, comment, func(f *Flags) {
+		f.Switches = nil
+		f.Set(FlagEnable, "", "")
+	})
+}
+
+func (c *Client) unlock(project, pkg string, comment string) error {
+	req, err := c.NewRequest(http.MethodPost, sourcePath(project, pkg), LockOptions{commandUnlock, comment}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// LockProject locks a project by enabling the lock flag in its meta,
+// so that neither it nor its packages can be modified.
+// The comment is recorded in the history of the meta.
+func (c *Client) LockProject(project string, comment string) error {
+	return c.lock(project, "", comment)
+}
+
+// UnlockProject unlocks a locked project.
+// OBS requires a comment explaining why the project is unlocked.
+func (c *Client) UnlockProject(project string, comment string) error {
+	return c.unlock(project, "", comment)
+}
+
+// LockPackage locks a package by enabling the lock flag in its meta.
+// The comment is recorded in the history of the meta.
+func (c *Client) LockPackage(project, pkg string, comment string) error {
+	return c.lock(project, pkg, comment)
+}
+
+// UnlockPackage unlocks a locked package.
+// OBS requires a comment explaining why the package is unlocked.
+func (c *Client) UnlockPackage(project, pkg string, comment string) error {
+	return c.unlock(project, pkg, comment)
+}
+
+// IsLocked checks whether a project, or a package if pkg is not empty,
+// is locked. A package in a locked project is locked as well.
+func (c *Client) IsLocked(project, pkg string) (bool, error) {
+	if pkg != "" {
+		meta, err := c.GetPackageMeta(project, pkg)
+		if err != nil {
+			return false, err
+		}
+		if meta.Flags(FlagLock).Status("", "") == FlagEnable {
+			return true, nil
+		}
+	}
+
+	meta, err := c.GetProjectMeta(project)
+	if err != nil {
+		return false, err
+	}
+
+	return meta.Flags(FlagLock).Status("", "") == FlagEnable, nil
+}
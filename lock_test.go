@@ -0,0 +1,137 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Locking", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a project is locked", func() {
+		It("should enable the lock flag with the comment", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/Product:1.0/_meta"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="Product:1.0">
+							<title>Product 1.0</title>
+							<description/>
+							<lock><disable/></lock>
+						</project>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/Product:1.0/_meta", "comment=Released+as+GA"),
+					ghttp.VerifyBody([]byte(unindent(`
+						<project name="Product:1.0">
+							<title>Product 1.0</title>
+							<description></description>
+							<lock><enable></enable></lock>
+						</project>`))),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.LockProject("Product:1.0", "Released as GA")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a package is unlocked", func() {
+		It("should pass the comment", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/Product:1.0/bar", "cmd=unlock&comment=Hotfix+for+bnc%231234"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.UnlockPackage("Product:1.0", "bar", "Hotfix for bnc#1234")
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("the lock flag is disabled or removed", func() {
+		It("should refuse without contacting OBS", func() {
+			err := c.SetFlag("Product:1.0", "", FlagLock, FlagDisable, "", "")
+			Expect(err).To(MatchError(ErrUnlockWithFlags))
+			err = c.RemoveFlag("Product:1.0", "bar", FlagLock, "", "")
+			Expect(err).To(MatchError(ErrUnlockWithFlags))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	When("the lock flag is set for a repository or architecture", func() {
+		It("should refuse without contacting OBS", func() {
+			err := c.SetFlag("Product:1.0", "", FlagLock, FlagEnable, "Debian_11", "")
+			Expect(err).To(HaveOccurred())
+			err = c.SetFlag("Product:1.0", "bar", FlagLock, FlagEnable, "", "x86_64")
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	When("a package in a locked project is checked", func() {
+		It("should be locked", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/Product:1.0/bar/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<package name="bar" project="Product:1.0">
+							<title>Bar</title>
+							<description/>
+						</package>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/Product:1.0/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="Product:1.0">
+							<title>Product 1.0</title>
+							<description/>
+							<lock><enable/></lock>
+						</project>`),
+				),
+			)
+			locked, err := c.IsLocked("Product:1.0", "bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locked).To(BeTrue())
+		})
+	})
+
+	When("an unlocked project is checked", func() {
+		It("should not be locked", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="home:foo">
+							<title>Home of foo</title>
+							<description/>
+						</project>`),
+				),
+			)
+			locked, err := c.IsLocked("home:foo", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(locked).To(BeFalse())
+		})
+	})
+})
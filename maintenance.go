@@ -0,0 +1,103 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+const (
+	commandCreateMaintenanceIncident = "createmaintenanceincident"
+
+	actionMaintenanceIncident = "maintenance_incident"
+	actionMaintenanceRelease  = "maintenance_release"
+)
+
+type MaintenanceOptions struct {
+	Command  string `url:"cmd,omitempty"`
+	NoAccess bool   `url:"noaccess,omitempty,int"`
+}
+
+// CreateMaintenanceIncident creates a new maintenance incident project
+// in the maintenance project and returns its name.
+// If noAccess is set, the incident is hidden until it is released,
+// which is useful for embargoed security fixes.
+func (c *Client) CreateMaintenanceIncident(maintenanceProject string, noAccess bool) (string, error) {
+	opt := MaintenanceOptions{
+		Command:  commandCreateMaintenanceIncident,
+		NoAccess: noAccess,
+	}
+	req, err := c.NewRequest(http.MethodPost, "/source/"+maintenanceProject, opt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var s status
+	_, err = c.Do(req, &s)
+	if err != nil {
+		return "", err
+	}
+
+	return s.data("targetproject"), nil
+}
+
+// ListMaintenanceIncidents gets a list of names of the incident
+// projects of a maintenance project.
+func (c *Client) ListMaintenanceIncidents(maintenanceProject string) ([]string, error) {
+	match := XPathAttrEquals("kind", "maintenance_incident").String() +
+		" and starts-with(@name," + xpathQuote(maintenanceProject+":") + ")"
+	req, err := c.NewRequest(http.MethodGet, "/search/project/id", SearchOptions{Match: match}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results projectCollection
+	_, err = c.Do(req, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []string
+	for _, p := range results.Projects {
+		incidents = append(incidents, p.Name)
+	}
+
+	return incidents, nil
+}
+
+// CreateMaintenanceIncidentRequest requests the sources to be merged
+// into a new incident of the maintenance project. Once released, the
+// updates end up in releaseProject, unless the sources specify
+// a release project themselves.
+func (c *Client) CreateMaintenanceIncidentRequest(maintenanceProject string, releaseProject string, sources []RequestSource, description string) (*Request, error) {
+	r := Request{Description: description}
+	for i := range sources {
+		r.Actions = append(r.Actions, RequestAction{
+			Type:   actionMaintenanceIncident,
+			Source: &sources[i],
+			Target: &RequestTarget{
+				Project:        maintenanceProject,
+				ReleaseProject: releaseProject,
+			},
+		})
+	}
+
+	return c.CreateRequest(&r)
+}
+
+// CreateMaintenanceReleaseRequest requests a maintenance incident to be
+// released into the release projects of its packages.
+func (c *Client) CreateMaintenanceReleaseRequest(incident string, description string) (*Request, error) {
+	r := Request{
+		Description: description,
+		Actions: []RequestAction{{
+			Type:   actionMaintenanceRelease,
+			Source: &RequestSource{Project: incident},
+		}},
+	}
+
+	return c.CreateRequest(&r)
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Maintenance", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a maintenance incident is created", func() {
+		It("should return the name of the incident project", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/foo:Maintenance", "cmd=createmaintenanceincident&noaccess=1"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<status code="ok">
+							<summary>Ok</summary>
+							<data name="targetproject">foo:Maintenance:42</data>
+						</status>`),
+				),
+			)
+			incident, err := c.CreateMaintenanceIncident("foo:Maintenance", true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(incident).To(Equal("foo:Maintenance:42"))
+		})
+	})
+
+	When("maintenance incidents are listed", func() {
+		It("should return the incident projects", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/search/project/id", "match=@kind='maintenance_incident' and starts-with(@name,'foo:Maintenance:')"),
+					ghttp.RespondWith(http.StatusOK, `
+						<collection matches="2">
+							<project name="foo:Maintenance:41"/>
+							<project name="foo:Maintenance:42"/>
+						</collection>`),
+				),
+			)
+			incidents, err := c.ListMaintenanceIncidents("foo:Maintenance")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(incidents).To(Equal([]string{"foo:Maintenance:41", "foo:Maintenance:42"}))
+		})
+	})
+
+	When("a maintenance incident request is created", func() {
+		It("should submit an action per source and return the request", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/request", "cmd=create"),
+					ghttp.VerifyBody([]byte(unindent(`
+						<request>
+							<action type="maintenance_incident">
+								<source project="home:foo:branches:bar" package="openssl"></source>
+								<target project="foo:Maintenance" releaseproject="foo:11:Update"></target>
+							</action>
+							<action type="maintenance_incident">
+								<source project="home:foo:branches:bar" package="curl" rev="3"></source>
+								<target project="foo:Maintenance" releaseproject="foo:11:Update"></target>
+							</action>
+							<description>Security fixes</description>
+						</request>`))),
+					ghttp.RespondWith(http.StatusOK, `
+						<request id="1234" creator="user">
+							<action type="maintenance_incident">
+								<source project="home:foo:branches:bar" package="openssl"/>
+								<target project="foo:Maintenance" releaseproject="foo:11:Update"/>
+							</action>
+							<action type="maintenance_incident">
+								<source project="home:foo:branches:bar" package="curl" rev="3"/>
+								<target project="foo:Maintenance" releaseproject="foo:11:Update"/>
+							</action>
+							<state name="new" who="user" when="2022-05-01T10:00:00"/>
+							<description>Security fixes</description>
+						</request>`),
+				),
+			)
+			r, err := c.CreateMaintenanceIncidentRequest("foo:Maintenance", "foo:11:Update", []RequestSource{
+				{Project: "home:foo:branches:bar", Package: "openssl"},
+				{Project: "home:foo:branches:bar", Package: "curl", Rev: "3"},
+			}, "Security fixes")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.ID).To(Equal(1234))
+			Expect(r.State.Name).To(Equal("new"))
+			Expect(r.Actions).To(HaveLen(2))
+		})
+	})
+
+	When("a maintenance release request is created", func() {
+		It("should return the request", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/request", "cmd=create"),
+					ghttp.VerifyBody([]byte(`<request><action type="maintenance_release"><source project="foo:Maintenance:42"></source></action><description>Release</description></request>`)),
+					ghttp.RespondWith(http.StatusOK, `
+						<request id="1235">
+							<action type="maintenance_release">
+								<source project="foo:Maintenance:42"/>
+							</action>
+							<state name="review"/>
+						</request>`),
+				),
+			)
+			r, err := c.CreateMaintenanceReleaseRequest("foo:Maintenance:42", "Release")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(r.ID).To(Equal(1235))
+		})
+	})
+
+	When("a patchinfo is created and updated", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/foo:Maintenance:42", "cmd=createpatchinfo"),
+					ghttp.RespondWith(http.StatusOK, `
+						<status code="ok">
+							<summary>Ok</summary>
+							<data name="targetproject">foo:Maintenance:42</data>
+							<data name="targetpackage">patchinfo</data>
+						</status>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/foo:Maintenance:42/patchinfo/_patchinfo"),
+					ghttp.VerifyBody([]byte(unindent(`
+						<patchinfo>
+							<issue tracker="cve" id="CVE-2022-0778">Infinite loop in BN_mod_sqrt()</issue>
+							<packager>user</packager>
+							<category>security</category>
+							<rating>important</rating>
+							<summary>Security update for openssl</summary>
+							<description>This update fixes a denial of service.</description>
+						</patchinfo>`))),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			pkg, err := c.CreatePatchinfo("foo:Maintenance:42", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pkg).To(Equal("patchinfo"))
+			err = c.UpdatePatchinfo("foo:Maintenance:42", pkg, &Patchinfo{
+				Issues: []PatchinfoIssue{
+					{"cve", "CVE-2022-0778", "Infinite loop in BN_mod_sqrt()"},
+				},
+				Packager:    "user",
+				Category:    "security",
+				Rating:      "important",
+				Summary:     "Security update for openssl",
+				Description: "This update fixes a denial of service.",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,55 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+)
+
+// Multibuild represents the _multibuild file of a package, which
+// defines flavours of the package built from the same sources.
+// Packages is the older name of flavours, still accepted by OBS.
+type Multibuild struct {
+	XMLName  xml.Name `xml:"multibuild" json:"-"`
+	Flavours []string `xml:"flavor"     json:"flavours,omitempty"`
+	Packages []string `xml:"package"    json:"packages,omitempty"`
+}
+
+// AllFlavours returns all flavours, however they are defined.
+func (m *Multibuild) AllFlavours() []string {
+	return append(append([]string(nil), m.Flavours...), m.Packages...)
+}
+
+// GetMultibuild retrieves the _multibuild file of a package.
+// If the package has none, nil is returned without an error.
+func (c *Client) GetMultibuild(project, pkg string) (*Multibuild, error) {
+	data, err := c.GetSourceFile(project, pkg, "_multibuild", SourceOptions{Expand: true})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Multibuild
+	err = xml.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// ListFlavours gets a list of the multibuild flavours of a package.
+// Use FlavourPackage to address them in build-related calls.
+func (c *Client) ListFlavours(project, pkg string) ([]string, error) {
+	m, err := c.GetMultibuild(project, pkg)
+	if err != nil || m == nil {
+		return nil, err
+	}
+
+	return m.AllFlavours(), nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Multibuild", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("flavours of a multibuild package are listed", func() {
+		It("should return all flavours", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/bar/_multibuild", "expand=1"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<multibuild>
+							<flavor>test</flavor>
+							<flavor>docs</flavor>
+							<package>legacy</package>
+						</multibuild>`),
+				),
+			)
+			ff, err := c.ListFlavours("home:foo", "bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ff).To(Equal([]string{"test", "docs", "legacy"}))
+		})
+	})
+
+	When("flavours of a plain package are listed", func() {
+		It("should return no flavours and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/baz/_multibuild"),
+					ghttp.RespondWith(http.StatusNotFound, `
+						<status code="404">
+							<summary>_multibuild: no such file</summary>
+						</status>`),
+				),
+			)
+			ff, err := c.ListFlavours("home:foo", "baz")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ff).To(BeEmpty())
+		})
+	})
+
+	When("flavours are addressed", func() {
+		It("should join and split the names", func() {
+			Expect(FlavourPackage("bar", "")).To(Equal("bar"))
+			Expect(FlavourPackage("bar", "test")).To(Equal("bar:test"))
+			pkg, flavour := SplitFlavour("bar:test")
+			Expect(pkg).To(Equal("bar"))
+			Expect(flavour).To(Equal("test"))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+)
+
+// Filters of notifications by their type.
+const (
+	NotificationsUnread           = "unread"
+	NotificationsRead             = "read"
+	NotificationsComments         = "comments"
+	NotificationsRequests         = "requests"
+	NotificationsIncomingRequests = "incoming_requests"
+	NotificationsOutgoingRequests = "outgoing_requests"
+	NotificationsBuildFailures    = "build_failures"
+)
+
+// Channels event subscriptions are delivered through.
+const (
+	ChannelDisabled     = "disabled"
+	ChannelInstantEmail = "instant_email"
+	ChannelWeb          = "web"
+)
+
+// Notification is a notification of the current user about an event,
+// e.g. a new request or a comment.
+type Notification struct {
+	ID            int    `xml:"id,attr"                  json:"id"`
+	Title         string `xml:"title"                    json:"title"`
+	Who           string `xml:"who,omitempty"            json:"who,omitempty"`
+	EventType     string `xml:"event_type"               json:"event_type"`
+	When          string `xml:"when"                     json:"when"`
+	RequestNumber int    `xml:"request_number,omitempty" json:"request_number,omitempty"`
+	Project       string `xml:"project,omitempty"        json:"project,omitempty"`
+	Package       string `xml:"package,omitempty"        json:"package,omitempty"`
+}
+
+type notificationList struct {
+	Count         int            `xml:"count,attr"`
+	TotalPages    int            `xml:"total_pages,attr"`
+	Notifications []Notification `xml:"notification"`
+}
+
+type NotificationOptions struct {
+	Type    string `url:"notifications_type,omitempty"`
+	Project string `url:"project,omitempty"`
+	Group   string `url:"group,omitempty"`
+	Page    int    `url:"page,omitempty"`
+}
+
+// EventSubscription is a subscription of the current user to an event
+// of a certain type in a certain role, e.g. to build failures of
+// packages they maintain.
+type EventSubscription struct {
+	EventType    string `xml:"eventtype,attr"     json:"event_type"`
+	ReceiverRole string `xml:"receiver_role,attr" json:"receiver_role"`
+	Channel      string `xml:"channel,attr"       json:"channel"`
+}
+
+type eventSubscriptions struct {
+	XMLName       xml.Name            `xml:"subscriptions"`
+	Subscriptions []EventSubscription `xml:"subscription"`
+}
+
+// ListNotifications retrieves the notifications of the current user.
+// Unless a particular page is requested, all pages are retrieved.
+func (c *Client) ListNotifications(opt *NotificationOptions) ([]Notification, error) {
+	if opt == nil {
+		opt = &NotificationOptions{}
+	}
+	o := *opt
+	if o.Page == 0 {
+		o.Page = 1
+	}
+
+	var notifications []Notification
+	for {
+		req, err := c.NewRequest(http.MethodGet, "/my/notifications", o, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var list notificationList
+		_, err = c.Do(req, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, list.Notifications...)
+		if opt.Page != 0 || o.Page >= list.TotalPages {
+			break
+		}
+		o.Page++
+	}
+
+	return notifications, nil
+}
+
+// ToggleNotification toggles the read state of a notification.
+func (c *Client) ToggleNotification(id int) error {
+	req, err := c.NewRequest(http.MethodPut, "/my/notifications/"+strconv.Itoa(id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setNotificationsState toggles those of the notifications which are
+// listed by the filter, leaving the rest alone.
+func (c *Client) setNotificationsState(filter string, ids []int) error {
+	notifications, err := c.ListNotifications(&NotificationOptions{Type: filter})
+	if err != nil {
+		return err
+	}
+
+	listed := make(map[int]bool)
+	for _, n := range notifications {
+		listed[n.ID] = true
+	}
+
+	for _, id := range ids {
+		if !listed[id] {
+			continue
+		}
+		err = c.ToggleNotification(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarkNotificationsRead marks notifications as read.
+// Notifications which have already been read are left alone.
+func (c *Client) MarkNotificationsRead(ids ...int) error {
+	return c.setNotificationsState(NotificationsUnread, ids)
+}
+
+// MarkNotificationsUnread marks notifications as unread.
+// Notifications which haven’t been read yet are left alone.
+func (c *Client) MarkNotificationsUnread(ids ...int) error {
+	return c.setNotificationsState(NotificationsRead, ids)
+}
+
+// GetEventSubscriptions retrieves the event subscriptions of the
+// current user.
+func (c *Client) GetEventSubscriptions() ([]EventSubscription, error) {
+	req, err := c.NewRequest(http.MethodGet, "/my/subscriptions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions eventSubscriptions
+	_, err = c.Do(req, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions.Subscriptions, nil
+}
+
+// SetEventSubscriptions updates the event subscriptions of the current
+// user. Subscriptions not mentioned are left unchanged.
+func (c *Client) SetEventSubscriptions(subscriptions []EventSubscription) error {
+	req, err := c.NewRequest(http.MethodPut, "/my/subscriptions", nil, eventSubscriptions{Subscriptions: subscriptions})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
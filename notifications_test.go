@@ -0,0 +1,142 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Notifications", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("unread notifications span several pages", func() {
+		It("should retrieve all of them", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/my/notifications", "notifications_type=unread&page=1&project=home%3Afoo"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<notifications count="2" total_pages="2" current_page="1">
+							<notification id="10">
+								<title>Request 1234 created by bar (submit)</title>
+								<who>bar</who>
+								<event_type>Event::RequestCreate</event_type>
+								<when>2022-05-01T10:00:00Z</when>
+								<request_number>1234</request_number>
+							</notification>
+						</notifications>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/my/notifications", "notifications_type=unread&page=2&project=home%3Afoo"),
+					ghttp.RespondWith(http.StatusOK, `
+						<notifications count="2" total_pages="2" current_page="2">
+							<notification id="11">
+								<title>Package bar failed to build</title>
+								<event_type>Event::BuildFail</event_type>
+								<when>2022-05-01T11:00:00Z</when>
+								<project>home:foo</project>
+								<package>bar</package>
+							</notification>
+						</notifications>`),
+				),
+			)
+			nn, err := c.ListNotifications(&NotificationOptions{Type: NotificationsUnread, Project: "home:foo"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nn).To(HaveLen(2))
+			Expect(nn[0].RequestNumber).To(Equal(1234))
+			Expect(nn[1]).To(Equal(Notification{
+				ID:        11,
+				Title:     "Package bar failed to build",
+				EventType: "Event::BuildFail",
+				When:      "2022-05-01T11:00:00Z",
+				Project:   "home:foo",
+				Package:   "bar",
+			}))
+		})
+	})
+
+	When("the server doesn’t report the current page", func() {
+		It("should still step through the pages", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/my/notifications", "page=1"),
+					ghttp.RespondWith(http.StatusOK, `
+						<notifications count="2" total_pages="2">
+							<notification id="10"><title>A</title><event_type>Event::CommentForRequest</event_type><when>2022-05-01T10:00:00Z</when></notification>
+						</notifications>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/my/notifications", "page=2"),
+					ghttp.RespondWith(http.StatusOK, `
+						<notifications count="2" total_pages="2">
+							<notification id="11"><title>B</title><event_type>Event::CommentForRequest</event_type><when>2022-05-01T11:00:00Z</when></notification>
+						</notifications>`),
+				),
+			)
+			nn, err := c.ListNotifications(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nn).To(HaveLen(2))
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	When("notifications are marked as read", func() {
+		It("should only toggle the unread ones", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/my/notifications", "notifications_type=unread&page=1"),
+					ghttp.RespondWith(http.StatusOK, `
+						<notifications count="1" total_pages="1" current_page="1">
+							<notification id="10">
+								<title>Request 1234 created by bar (submit)</title>
+								<event_type>Event::RequestCreate</event_type>
+								<when>2022-05-01T10:00:00Z</when>
+							</notification>
+						</notifications>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/my/notifications/10"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.MarkNotificationsRead(10, 11)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(2))
+		})
+	})
+
+	When("event subscriptions are updated", func() {
+		It("should send them to the server", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/my/subscriptions"),
+					ghttp.VerifyBody([]byte(`<subscriptions><subscription eventtype="Event::BuildFail" receiver_role="maintainer" channel="web"></subscription></subscriptions>`)),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.SetEventSubscriptions([]EventSubscription{
+				{"Event::BuildFail", "maintainer", ChannelWeb},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
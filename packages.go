@@ -0,0 +1,85 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// PackageRef refers to a package in a project.
+type PackageRef struct {
+	Project string `xml:"project,attr" json:"project"`
+	Name    string `xml:"name,attr"    json:"name"`
+}
+
+type packageCollection struct {
+	Packages []PackageRef `xml:"package"`
+}
+
+// PackageMeta represents the meta data of a package: its description,
+// users and groups with their roles and build flags.
+// The fields follow the order of the OBS package schema, as OBS
+// validates the element order. Elements not modelled here are kept in
+// Other, so that they survive modifying the meta.
+type PackageMeta struct {
+	XMLName        xml.Name     `xml:"package"                  json:"-"`
+	Name           string       `xml:"name,attr"                json:"name"`
+	Project        string       `xml:"project,attr"             json:"project"`
+	Title          string       `xml:"title"                    json:"title"`
+	Description    string       `xml:"description"              json:"description"`
+	Devel          *DevelRef    `xml:"devel,omitempty"          json:"devel,omitempty"`
+	ReleaseName    string       `xml:"releasename,omitempty"    json:"releasename,omitempty"`
+	Persons        []PersonRole `xml:"person"                   json:"persons,omitempty"`
+	Groups         []GroupRole  `xml:"group"                    json:"groups,omitempty"`
+	Lock           *Flags       `xml:"lock,omitempty"           json:"-"`
+	Build          *Flags       `xml:"build,omitempty"          json:"-"`
+	Publish        *Flags       `xml:"publish,omitempty"        json:"-"`
+	DebugInfo      *Flags       `xml:"debuginfo,omitempty"      json:"-"`
+	UseForBuild    *Flags       `xml:"useforbuild,omitempty"    json:"-"`
+	BinaryDownload *Flags       `xml:"binarydownload,omitempty" json:"-"`
+	SourceAccess   *Flags       `xml:"sourceaccess,omitempty"   json:"-"`
+	URL            string       `xml:"url,omitempty"            json:"url,omitempty"`
+	SCMSync        string       `xml:"scmsync,omitempty"        json:"scmsync,omitempty"`
+	BcntSyncTag    string       `xml:"bcntsynctag,omitempty"    json:"bcntsynctag,omitempty"`
+	Other          []rawElement `xml:",any"                     json:"-"`
+}
+
+// GetPackageMeta retrieves the meta data of a package.
+func (c *Client) GetPackageMeta(project, pkg string) (*PackageMeta, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg+"/_meta", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta PackageMeta
+	_, err = c.Do(req, &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
+
+// SetPackageMeta replaces the meta data of a package, creating the
+// package if it doesn’t exist yet.
+func (c *Client) SetPackageMeta(meta *PackageMeta) error {
+	return c.putPackageMeta(meta, "")
+}
+
+func (c *Client) putPackageMeta(meta *PackageMeta, comment string) error {
+	req, err := c.NewRequest(http.MethodPut, "/source/"+meta.Project+"/"+meta.Name+"/_meta", MetaOptions{Comment: comment}, meta)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
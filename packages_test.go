@@ -0,0 +1,38 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Package meta", func() {
+	When("package meta is unmarshalled and marshalled again", func() {
+		It("should preserve the elements it doesn’t model", func() {
+			input := unindent(`
+				<package name="bar" project="home:foo">
+					<title>Bar</title>
+					<description></description>
+					<devel project="devel:bar"></devel>
+					<person userid="foo" role="maintainer"></person>
+					<lock><enable></enable></lock>
+					<url>https://bar.example.com/</url>
+					<scmsync>https://git.example.com/bar.git</scmsync>
+					<frobnicate level="3"><widget>bar</widget></frobnicate>
+				</package>`)
+			var meta PackageMeta
+			err := xml.Unmarshal([]byte(input), &meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.Other).To(HaveLen(1))
+			data, err := xml.Marshal(meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		})
+	})
+})
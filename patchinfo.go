@@ -0,0 +1,204 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	commandCreatePatchinfo = "createpatchinfo"
+)
+
+// Categories of maintenance updates.
+const (
+	PatchinfoSecurity    = "security"
+	PatchinfoRecommended = "recommended"
+	PatchinfoOptional    = "optional"
+	PatchinfoFeature     = "feature"
+)
+
+// Ratings of maintenance updates.
+const (
+	PatchinfoLow         = "low"
+	PatchinfoModerate    = "moderate"
+	PatchinfoImportant   = "important"
+	PatchinfoCritical    = "critical"
+	PatchinfoUnspecified = "unspecified"
+)
+
+// PatchinfoIssue refers to an issue fixed by an update,
+// e.g. a bug report or a CVE.
+type PatchinfoIssue struct {
+	Tracker string `xml:"tracker,attr" json:"tracker"`
+	ID      string `xml:"id,attr"      json:"id"`
+	Summary string `xml:",chardata"    json:"summary,omitempty"`
+}
+
+// PatchinfoFlag is a flag represented by the presence of an empty element.
+type PatchinfoFlag bool
+
+func (f PatchinfoFlag) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !f {
+		return nil
+	} else {
+		return e.EncodeElement("", start)
+	}
+}
+
+func (f *PatchinfoFlag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*f = true
+	return d.Skip()
+}
+
+// PatchinfoReleaseTarget limits the release of an update to a repository.
+type PatchinfoReleaseTarget struct {
+	Project    string `xml:"project,attr"              json:"project"`
+	Repository string `xml:"repository,attr,omitempty" json:"repository,omitempty"`
+}
+
+// Patchinfo describes a maintenance update.
+// Packages, Binaries and ReleaseTargets limit the update to some of the
+// packages, binaries or repositories of the incident. Elements not
+// modelled here are kept in Other, so that they survive an update.
+type Patchinfo struct {
+	XMLName           xml.Name                 `xml:"patchinfo"               json:"-"`
+	Incident          string                   `xml:"incident,attr,omitempty" json:"incident,omitempty"`
+	Issues            []PatchinfoIssue         `xml:"issue"                   json:"issues,omitempty"`
+	Packages          []string                 `xml:"package"                 json:"packages,omitempty"`
+	Binaries          []string                 `xml:"binary"                  json:"binaries,omitempty"`
+	ReleaseTargets    []PatchinfoReleaseTarget `xml:"releasetarget"           json:"releasetargets,omitempty"`
+	Packager          string                   `xml:"packager"                json:"packager"`
+	Category          string                   `xml:"category"                json:"category"`
+	Rating            string                   `xml:"rating"                  json:"rating"`
+	Name              string                   `xml:"name,omitempty"          json:"name,omitempty"`
+	Summary           string                   `xml:"summary"                 json:"summary"`
+	Description       string                   `xml:"description"             json:"description"`
+	Message           string                   `xml:"message,omitempty"       json:"message,omitempty"`
+	RebootNeeded      PatchinfoFlag            `xml:"reboot_needed"           json:"reboot_needed,omitempty"`
+	ReloginNeeded     PatchinfoFlag            `xml:"relogin_needed"          json:"relogin_needed,omitempty"`
+	ZyppRestartNeeded PatchinfoFlag            `xml:"zypp_restart_needed"     json:"zypp_restart_needed,omitempty"`
+	Stopped           PatchinfoFlag            `xml:"stopped"                 json:"stopped,omitempty"`
+	Retracted         PatchinfoFlag            `xml:"retracted"               json:"retracted,omitempty"`
+	Other             []rawElement             `xml:",any"                    json:"-"`
+}
+
+func oneOf(value string, values ...string) bool {
+	for _, v := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks that all required fields of the patchinfo are set,
+// and that the category and the rating have known values.
+func (pi *Patchinfo) Validate() error {
+	var problems []string
+
+	if pi.Packager == "" {
+		problems = append(problems, "packager is missing")
+	}
+
+	if pi.Summary == "" {
+		problems = append(problems, "summary is missing")
+	}
+
+	if pi.Description == "" {
+		problems = append(problems, "description is missing")
+	}
+
+	if !oneOf(pi.Category, PatchinfoSecurity, PatchinfoRecommended, PatchinfoOptional, PatchinfoFeature) {
+		problems = append(problems, fmt.Sprintf("unknown category '%s'", pi.Category))
+	}
+
+	if !oneOf(pi.Rating, PatchinfoLow, PatchinfoModerate, PatchinfoImportant, PatchinfoCritical, PatchinfoUnspecified) {
+		problems = append(problems, fmt.Sprintf("unknown rating '%s'", pi.Rating))
+	}
+
+	for _, issue := range pi.Issues {
+		if issue.Tracker == "" || issue.ID == "" {
+			problems = append(problems, "issue without a tracker or an ID")
+			break
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid patchinfo: %s", strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+type PatchinfoOptions struct {
+	Command string `url:"cmd,omitempty"`
+	Name    string `url:"name,omitempty"`
+}
+
+// CreatePatchinfo creates a patchinfo package in a project, usually a
+// maintenance incident, and returns the name of the package. If name is
+// empty, the package is called patchinfo. The patchinfo is filled with
+// default values; use UpdatePatchinfo to describe the update.
+func (c *Client) CreatePatchinfo(project string, name string) (string, error) {
+	opt := PatchinfoOptions{
+		Command: commandCreatePatchinfo,
+		Name:    name,
+	}
+	req, err := c.NewRequest(http.MethodPost, "/source/"+project, opt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var s status
+	_, err = c.Do(req, &s)
+	if err != nil {
+		return "", err
+	}
+
+	return s.data("targetpackage"), nil
+}
+
+// GetPatchinfo retrieves the patchinfo from the package.
+func (c *Client) GetPatchinfo(project string, pkg string) (*Patchinfo, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg+"/_patchinfo", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pi Patchinfo
+	_, err = c.Do(req, &pi)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pi, nil
+}
+
+// UpdatePatchinfo replaces the patchinfo in the package.
+// The patchinfo is validated before it is sent to OBS.
+func (c *Client) UpdatePatchinfo(project string, pkg string, pi *Patchinfo) error {
+	err := pi.Validate()
+	if err != nil {
+		return err
+	}
+
+	req, err := c.NewRequest(http.MethodPut, "/source/"+project+"/"+pkg+"/_patchinfo", nil, pi)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
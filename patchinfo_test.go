@@ -0,0 +1,150 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Marshalling", func() {
+	When("a patchinfo is unmarshalled and marshalled again", func() {
+		It("should preserve the flags", func() {
+			input := unindent(`
+				<patchinfo incident="42">
+					<issue tracker="cve" id="CVE-2022-0778">Infinite loop in BN_mod_sqrt()</issue>
+					<issue tracker="bnc" id="1196877"></issue>
+					<packager>user</packager>
+					<category>security</category>
+					<rating>important</rating>
+					<name>openssl</name>
+					<summary>Security update for openssl</summary>
+					<description>This update fixes a denial of service.</description>
+					<reboot_needed></reboot_needed>
+					<retracted></retracted>
+				</patchinfo>`)
+			var pi Patchinfo
+			err := xml.Unmarshal([]byte(input), &pi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pi.Incident).To(Equal("42"))
+			Expect(pi.Issues).To(HaveLen(2))
+			Expect(pi.RebootNeeded).To(Equal(PatchinfoFlag(true)))
+			Expect(pi.ZyppRestartNeeded).To(Equal(PatchinfoFlag(false)))
+			Expect(pi.Stopped).To(Equal(PatchinfoFlag(false)))
+			Expect(pi.Retracted).To(Equal(PatchinfoFlag(true)))
+			data, err := xml.Marshal(pi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		})
+	})
+})
+
+var _ = Describe("Patchinfo", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a patchinfo is validated", func() {
+		It("should report all problems", func() {
+			pi := Patchinfo{
+				Category: "bugfix",
+				Rating:   PatchinfoLow,
+				Summary:  "Update for foo",
+				Issues:   []PatchinfoIssue{{Tracker: "bnc"}},
+			}
+			Expect(pi.Validate()).To(MatchError("invalid patchinfo: packager is missing, description is missing, unknown category 'bugfix', issue without a tracker or an ID"))
+			pi.Packager = "user"
+			pi.Description = "Fixes foo"
+			pi.Category = PatchinfoRecommended
+			pi.Issues[0].ID = "1"
+			Expect(pi.Validate()).To(Succeed())
+		})
+	})
+
+	When("a patchinfo is requested", func() {
+		It("should return it and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/foo:Maintenance:42/patchinfo/_patchinfo"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<patchinfo incident="42">
+							<packager>user</packager>
+							<category>recommended</category>
+							<rating>low</rating>
+							<summary>Update for foo</summary>
+							<description>Fixes foo</description>
+							<zypp_restart_needed/>
+						</patchinfo>`),
+				),
+			)
+			pi, err := c.GetPatchinfo("foo:Maintenance:42", "patchinfo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pi.Category).To(Equal(PatchinfoRecommended))
+			Expect(pi.ZyppRestartNeeded).To(Equal(PatchinfoFlag(true)))
+		})
+	})
+
+	When("a patchinfo is retrieved and updated", func() {
+		It("should keep the restrictions and unknown elements", func() {
+			input := unindent(`
+				<patchinfo incident="42">
+					<issue tracker="cve" id="2022-1234"></issue>
+					<package>foo</package>
+					<binary>foo-devel</binary>
+					<releasetarget project="foo:1.0:Update" repository="standard"></releasetarget>
+					<packager>user</packager>
+					<category>security</category>
+					<rating>important</rating>
+					<summary>Security update for foo</summary>
+					<description>Fixes CVE-2022-1234</description>
+					<message>Please restart foo after the update.</message>
+					<block>true</block>
+					<block_reason>Waiting for QA</block_reason>
+				</patchinfo>`)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/foo:Maintenance:42/patchinfo/_patchinfo"),
+					ghttp.RespondWith(http.StatusOK, input),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPut, "/source/foo:Maintenance:42/patchinfo/_patchinfo"),
+					ghttp.VerifyBody([]byte(input)),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			pi, err := c.GetPatchinfo("foo:Maintenance:42", "patchinfo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pi.Packages).To(Equal([]string{"foo"}))
+			Expect(pi.Binaries).To(Equal([]string{"foo-devel"}))
+			Expect(pi.ReleaseTargets).To(Equal([]PatchinfoReleaseTarget{{"foo:1.0:Update", "standard"}}))
+			err = c.UpdatePatchinfo("foo:Maintenance:42", "patchinfo", pi)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("an invalid patchinfo is being updated", func() {
+		It("should return an error without contacting OBS", func() {
+			err := c.UpdatePatchinfo("foo:Maintenance:42", "patchinfo", &Patchinfo{})
+			Expect(err).To(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,298 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ConfigLineKind tells what kind of line of a project configuration
+// a ConfigLine is.
+type ConfigLineKind int
+
+const (
+	// ConfigBlank is an empty or whitespace-only line.
+	ConfigBlank ConfigLineKind = iota
+	// ConfigComment is a comment starting with #.
+	ConfigComment
+	// ConfigDirective is a directive like Prefer: or Type:.
+	ConfigDirective
+	// ConfigConditional is one of %if, %ifarch, %else, %endif etc.
+	ConfigConditional
+	// ConfigMacro is a macro definition outside a Macros block,
+	// or any other line starting with a %.
+	ConfigMacro
+	// ConfigMacrosStart is the Macros: line opening a macros block.
+	ConfigMacrosStart
+	// ConfigMacrosBody is a line inside a macros block.
+	ConfigMacrosBody
+	// ConfigMacrosEnd is the :Macros line closing a macros block, which
+	// may be missing if the block runs to the end of the configuration.
+	ConfigMacrosEnd
+	// ConfigOther is a line the parser doesn’t understand.
+	ConfigOther
+)
+
+// ConfigLine is a single line of a project configuration.
+type ConfigLine struct {
+	// Text is the line as it appears in the configuration.
+	Text string
+	Kind ConfigLineKind
+
+	// Directive and Value are the name and the value of a directive,
+	// with the whitespace around the value trimmed.
+	Directive string
+	Value     string
+
+	// Conditions are the conditions of the %if blocks enclosing the line,
+	// outermost first. In %elif and %else branches, the conditions of the
+	// preceding branches are prefixed by !.
+	Conditions []string
+}
+
+// Values splits the value of a directive into whitespace-separated words.
+func (l *ConfigLine) Values() []string {
+	return strings.Fields(l.Value)
+}
+
+// SetValue replaces the value of a directive, keeping the name as spelt
+// in the original line.
+func (l *ConfigLine) SetValue(value string) {
+	l.Value = value
+	l.Text = l.Directive + ": " + value
+}
+
+// ProjectConfig represents a parsed project configuration (prjconf).
+// It keeps all lines verbatim, so that a configuration can be modified
+// without losing its formatting; String returns the text back.
+type ProjectConfig struct {
+	Lines []*ConfigLine
+}
+
+var configDirectiveRE = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_]*)\s*:(.*)$`)
+
+// ParseProjectConfig parses the text of a project configuration.
+// An error is returned if %if blocks aren’t closed, but all of the lines
+// are still available in the returned config. The macros block may run
+// to the end of the configuration.
+func ParseProjectConfig(text string) (*ProjectConfig, error) {
+	var (
+		config     ProjectConfig
+		conditions []string
+		levels     []int // indices of the %if conditions in conditions
+		inMacros   bool
+		err        error
+	)
+
+	for n, text := range strings.Split(text, "\n") {
+		line := ConfigLine{Text: text}
+		trimmed := strings.TrimSpace(text)
+
+		switch {
+		case inMacros && trimmed == ":Macros":
+			line.Kind = ConfigMacrosEnd
+			inMacros = false
+
+		case inMacros:
+			line.Kind = ConfigMacrosBody
+
+		case trimmed == "":
+			line.Kind = ConfigBlank
+
+		case strings.HasPrefix(trimmed, "#"):
+			line.Kind = ConfigComment
+
+		case strings.HasPrefix(trimmed, "%"):
+			line.Kind = ConfigMacro
+			word := strings.Fields(trimmed)[0]
+			switch {
+			case strings.HasPrefix(word, "%if"):
+				line.Kind = ConfigConditional
+				levels = append(levels, len(conditions))
+				conditions = append(conditions, trimmed)
+			case strings.HasPrefix(word, "%elif"), word == "%else":
+				line.Kind = ConfigConditional
+				if len(levels) == 0 {
+					if err == nil {
+						err = fmt.Errorf("line %d: %s without %%if", n+1, word)
+					}
+					break
+				}
+				conditions[len(conditions)-1] = "!" + conditions[len(conditions)-1]
+				if word != "%else" {
+					conditions = append(conditions, trimmed)
+				}
+			case word == "%endif":
+				line.Kind = ConfigConditional
+				if len(levels) == 0 {
+					if err == nil {
+						err = fmt.Errorf("line %d: %%endif without %%if", n+1)
+					}
+					break
+				}
+				conditions = conditions[:levels[len(levels)-1]]
+				levels = levels[:len(levels)-1]
+			}
+
+		case strings.EqualFold(trimmed, "Macros:"):
+			line.Kind = ConfigMacrosStart
+			inMacros = true
+
+		default:
+			if m := configDirectiveRE.FindStringSubmatch(trimmed); m != nil {
+				line.Kind = ConfigDirective
+				line.Directive = m[1]
+				line.Value = strings.TrimSpace(m[2])
+			} else {
+				line.Kind = ConfigOther
+			}
+		}
+
+		if line.Kind != ConfigConditional && len(conditions) > 0 {
+			line.Conditions = append([]string(nil), conditions...)
+		}
+
+		config.Lines = append(config.Lines, &line)
+	}
+
+	if err == nil && len(levels) > 0 {
+		err = fmt.Errorf("unterminated %s", strings.TrimPrefix(conditions[levels[len(levels)-1]], "!"))
+	}
+
+	return &config, err
+}
+
+// String returns the text of the configuration.
+func (pc *ProjectConfig) String() string {
+	lines := make([]string, len(pc.Lines))
+	for i, l := range pc.Lines {
+		lines[i] = l.Text
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Directives returns all lines with the named directive, including those
+// inside %if blocks. Directive names are case-insensitive.
+func (pc *ProjectConfig) Directives(name string) []*ConfigLine {
+	var lines []*ConfigLine
+	for _, l := range pc.Lines {
+		if l.Kind == ConfigDirective && strings.EqualFold(l.Directive, name) {
+			lines = append(lines, l)
+		}
+	}
+
+	return lines
+}
+
+// Get returns the value of the first unconditional occurrence of
+// the named directive, and whether it has been found.
+func (pc *ProjectConfig) Get(name string) (string, bool) {
+	for _, l := range pc.Directives(name) {
+		if len(l.Conditions) == 0 {
+			return l.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Set sets the value of the first unconditional occurrence of the named
+// directive. If there is none, the directive is appended before the
+// macros block, or at the end of the configuration if there isn’t one.
+func (pc *ProjectConfig) Set(name, value string) {
+	for _, l := range pc.Directives(name) {
+		if len(l.Conditions) == 0 {
+			l.SetValue(value)
+			return
+		}
+	}
+
+	line := &ConfigLine{
+		Kind:      ConfigDirective,
+		Directive: name,
+	}
+	line.SetValue(value)
+
+	pos := len(pc.Lines)
+	for i, l := range pc.Lines {
+		if l.Kind == ConfigMacrosStart {
+			pos = i
+			break
+		}
+	}
+
+	// Keep the trailing newline last
+	if pos == len(pc.Lines) && pos > 0 && pc.Lines[pos-1].Text == "" {
+		pos--
+	}
+
+	pc.Lines = append(pc.Lines[:pos], append([]*ConfigLine{line}, pc.Lines[pos:]...)...)
+}
+
+// Remove removes all unconditional occurrences of the named directive
+// and returns the number of lines removed.
+func (pc *ProjectConfig) Remove(name string) int {
+	var lines []*ConfigLine
+	for _, l := range pc.Lines {
+		if l.Kind == ConfigDirective && strings.EqualFold(l.Directive, name) && len(l.Conditions) == 0 {
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	removed := len(pc.Lines) - len(lines)
+	pc.Lines = lines
+
+	return removed
+}
+
+// Macros returns the contents of the macros block.
+func (pc *ProjectConfig) Macros() string {
+	var lines []string
+	for _, l := range pc.Lines {
+		if l.Kind == ConfigMacrosBody {
+			lines = append(lines, l.Text)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// GetProjectConfig retrieves the text of the project configuration.
+// Use ParseProjectConfig to inspect it.
+func (c *Client) GetProjectConfig(project string) (string, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/_config", nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var config strings.Builder
+	_, err = c.Do(req, &config)
+	if err != nil {
+		return "", err
+	}
+
+	return config.String(), nil
+}
+
+// SetProjectConfig replaces the project configuration.
+func (c *Client) SetProjectConfig(project string, config string) error {
+	req, err := c.NewRequest(http.MethodPut, "/source/"+project+"/_config", nil, config)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
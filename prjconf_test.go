@@ -0,0 +1,167 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const debianConfig = `Type: dsc
+Repotype: debian
+# Prefer the default implementations
+Prefer:  libncurses6  debhelper
+%if 0%{?debian_version} >= 1100
+Substitute: python python3
+%else
+Ignore: base-files:gawk
+%endif
+   Keep: dpkg
+Macros:
+%debian_version 1100
+%if 1
+:Macros
+`
+
+var _ = Describe("Project configuration", func() {
+	When("a configuration is parsed", func() {
+		It("should recognise all kinds of lines", func() {
+			pc, err := ParseProjectConfig(debianConfig)
+			Expect(err).ToNot(HaveOccurred())
+			var kinds []ConfigLineKind
+			for _, l := range pc.Lines {
+				kinds = append(kinds, l.Kind)
+			}
+			Expect(kinds).To(Equal([]ConfigLineKind{
+				ConfigDirective,
+				ConfigDirective,
+				ConfigComment,
+				ConfigDirective,
+				ConfigConditional,
+				ConfigDirective,
+				ConfigConditional,
+				ConfigDirective,
+				ConfigConditional,
+				ConfigDirective,
+				ConfigMacrosStart,
+				ConfigMacrosBody,
+				ConfigMacrosBody,
+				ConfigMacrosEnd,
+				ConfigBlank,
+			}))
+			Expect(pc.Lines[3].Values()).To(Equal([]string{"libncurses6", "debhelper"}))
+			Expect(pc.Lines[5].Conditions).To(Equal([]string{"%if 0%{?debian_version} >= 1100"}))
+			Expect(pc.Lines[7].Conditions).To(Equal([]string{"!%if 0%{?debian_version} >= 1100"}))
+			Expect(pc.Macros()).To(Equal("%debian_version 1100\n%if 1"))
+			Expect(pc.String()).To(Equal(debianConfig))
+		})
+
+		It("should accept a macros block running to the end", func() {
+			pc, err := ParseProjectConfig("Type: dsc\nMacros:\n%foo 1\n%bar 2\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pc.Lines[2].Kind).To(Equal(ConfigMacrosBody))
+			Expect(pc.Macros()).To(Equal("%foo 1\n%bar 2\n"))
+		})
+
+		It("should track %elif branches", func() {
+			pc, err := ParseProjectConfig("%if A\nPrefer: a\n%elif B\nPrefer: b\n%else\nPrefer: c\n%endif\nPrefer: d")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pc.Lines[2].Kind).To(Equal(ConfigConditional))
+			Expect(pc.Lines[1].Conditions).To(Equal([]string{"%if A"}))
+			Expect(pc.Lines[3].Conditions).To(Equal([]string{"!%if A", "%elif B"}))
+			Expect(pc.Lines[5].Conditions).To(Equal([]string{"!%if A", "!%elif B"}))
+			Expect(pc.Lines[7].Conditions).To(BeEmpty())
+		})
+
+		It("should return an error for unterminated blocks", func() {
+			_, err := ParseProjectConfig("%if 1\nType: dsc\n")
+			Expect(err).To(MatchError("unterminated %if 1"))
+			_, err = ParseProjectConfig("%if A\n%elif B\n")
+			Expect(err).To(MatchError("unterminated %if A"))
+			_, err = ParseProjectConfig("Type: dsc\n%endif\n")
+			Expect(err).To(MatchError("line 2: %endif without %if"))
+		})
+	})
+
+	When("directives are modified", func() {
+		It("should keep the rest of the configuration intact", func() {
+			pc, err := ParseProjectConfig(debianConfig)
+			Expect(err).ToNot(HaveOccurred())
+			value, found := pc.Get("repotype")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("debian"))
+			_, found = pc.Get("Substitute")
+			Expect(found).To(BeFalse())
+			pc.Set("Repotype", "debian:nodebug")
+			pc.Set("Release", "<CI_CNT>.<B_CNT>")
+			Expect(pc.Remove("keep")).To(Equal(1))
+			Expect(pc.String()).To(Equal(`Type: dsc
+Repotype: debian:nodebug
+# Prefer the default implementations
+Prefer:  libncurses6  debhelper
+%if 0%{?debian_version} >= 1100
+Substitute: python python3
+%else
+Ignore: base-files:gawk
+%endif
+Release: <CI_CNT>.<B_CNT>
+Macros:
+%debian_version 1100
+%if 1
+:Macros
+`))
+		})
+	})
+
+	Describe("API", func() {
+		var (
+			server *ghttp.Server
+			c      *Client
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		When("the configuration of a project is requested", func() {
+			It("should return its text and no error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodGet, "/source/Debian:11/_config"),
+						ghttp.VerifyBasicAuth(username, password),
+						ghttp.RespondWith(http.StatusOK, debianConfig),
+					),
+				)
+				config, err := c.GetProjectConfig("Debian:11")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).To(Equal(debianConfig))
+			})
+		})
+
+		When("the configuration of a project is set", func() {
+			It("should return no error", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest(http.MethodPut, "/source/Debian:11/_config"),
+						ghttp.VerifyBasicAuth(username, password),
+						ghttp.VerifyBody([]byte(debianConfig)),
+						ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+					),
+				)
+				err := c.SetProjectConfig("Debian:11", debianConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+})
@@ -5,8 +5,164 @@
 
 package obs
 
+import (
+	"encoding/xml"
+	"net/http"
+)
+
 // ProjectRef represents a project referred by its name.
 // This is used e.g. to represent a project in a watchlist.
 type ProjectRef struct {
 	Name string `xml:"name,attr" json:"name"`
 }
+
+type projectCollection struct {
+	Projects []ProjectRef `xml:"project"`
+}
+
+// PersonRole assigns a role (e.g. maintainer or bugowner) to a user.
+type PersonRole struct {
+	ID   string `xml:"userid,attr" json:"username"`
+	Role string `xml:"role,attr"   json:"role"`
+}
+
+// GroupRole assigns a role (e.g. maintainer or reviewer) to a group.
+type GroupRole struct {
+	ID   string `xml:"groupid,attr" json:"group"`
+	Role string `xml:"role,attr"    json:"role"`
+}
+
+// ProjectLink refers to a project the sources are inherited from.
+type ProjectLink struct {
+	Project  string `xml:"project,attr"            json:"project"`
+	VRevMode string `xml:"vrevmode,attr,omitempty" json:"vrevmode,omitempty"`
+}
+
+// PathEntry refers to a repository of another project which is used to
+// resolve build dependencies.
+type PathEntry struct {
+	Project    string `xml:"project,attr"    json:"project"`
+	Repository string `xml:"repository,attr" json:"repository"`
+}
+
+// rawElement keeps an element the meta types don’t model verbatim.
+type rawElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+// ReleaseTarget refers to a repository the builds of a repository
+// are released into. Trigger tells when the release happens:
+// manually, on a maintenance release or never (empty).
+type ReleaseTarget struct {
+	Project    string `xml:"project,attr"           json:"project"`
+	Repository string `xml:"repository,attr"        json:"repository"`
+	Trigger    string `xml:"trigger,attr,omitempty" json:"trigger,omitempty"`
+}
+
+// Repository represents a build repository of a project.
+type Repository struct {
+	Name           string          `xml:"name,attr"                  json:"name"`
+	Rebuild        string          `xml:"rebuild,attr,omitempty"     json:"rebuild,omitempty"`
+	Block          string          `xml:"block,attr,omitempty"       json:"block,omitempty"`
+	LinkedBuild    string          `xml:"linkedbuild,attr,omitempty" json:"linkedbuild,omitempty"`
+	Downloads      []rawElement    `xml:"download"                   json:"-"`
+	ReleaseTargets []ReleaseTarget `xml:"releasetarget"              json:"releasetargets,omitempty"`
+	HostSystem     *PathEntry      `xml:"hostsystem,omitempty"       json:"hostsystem,omitempty"`
+	Paths          []PathEntry     `xml:"path"                       json:"paths,omitempty"`
+	Architectures  []string        `xml:"arch"                       json:"architectures,omitempty"`
+}
+
+// DevelRef refers to the project, and for packages the package, where
+// a project or a package is developed. An empty Package refers to the
+// package of the same name.
+type DevelRef struct {
+	Project string `xml:"project,attr"           json:"project"`
+	Package string `xml:"package,attr,omitempty" json:"package,omitempty"`
+}
+
+// MaintainedProject refers to a project maintained through
+// a maintenance project.
+type MaintainedProject struct {
+	Project string `xml:"project,attr" json:"project"`
+}
+
+// ProjectMaintenance lists the projects a maintenance project maintains.
+type ProjectMaintenance struct {
+	Maintains []MaintainedProject `xml:"maintains" json:"maintains,omitempty"`
+}
+
+// ProjectMeta represents the meta data of a project: its description,
+// users and groups with their roles, build flags and repositories.
+// The fields follow the order of the OBS project schema, as OBS
+// validates the element order. Elements not modelled here are kept in
+// Other, so that they survive modifying the meta.
+type ProjectMeta struct {
+	XMLName        xml.Name            `xml:"project"                  json:"-"`
+	Name           string              `xml:"name,attr"                json:"name"`
+	Kind           string              `xml:"kind,attr,omitempty"      json:"kind,omitempty"`
+	Title          string              `xml:"title"                    json:"title"`
+	Description    string              `xml:"description"              json:"description"`
+	URL            string              `xml:"url,omitempty"            json:"url,omitempty"`
+	Links          []ProjectLink       `xml:"link"                     json:"links,omitempty"`
+	MountProject   *rawElement         `xml:"mountproject,omitempty"   json:"-"`
+	RemoteURL      string              `xml:"remoteurl,omitempty"      json:"remoteurl,omitempty"`
+	RemoteProject  string              `xml:"remoteproject,omitempty"  json:"remoteproject,omitempty"`
+	SCMSync        string              `xml:"scmsync,omitempty"        json:"scmsync,omitempty"`
+	Devel          *DevelRef           `xml:"devel,omitempty"          json:"devel,omitempty"`
+	Persons        []PersonRole        `xml:"person"                   json:"persons,omitempty"`
+	Groups         []GroupRole         `xml:"group"                    json:"groups,omitempty"`
+	Lock           *Flags              `xml:"lock,omitempty"           json:"-"`
+	Build          *Flags              `xml:"build,omitempty"          json:"-"`
+	Publish        *Flags              `xml:"publish,omitempty"        json:"-"`
+	DebugInfo      *Flags              `xml:"debuginfo,omitempty"      json:"-"`
+	UseForBuild    *Flags              `xml:"useforbuild,omitempty"    json:"-"`
+	BinaryDownload *Flags              `xml:"binarydownload,omitempty" json:"-"`
+	SourceAccess   *Flags              `xml:"sourceaccess,omitempty"   json:"-"`
+	Access         *Flags              `xml:"access,omitempty"         json:"-"`
+	Maintenance    *ProjectMaintenance `xml:"maintenance,omitempty"    json:"maintenance,omitempty"`
+	Other          []rawElement        `xml:",any"                     json:"-"`
+	Repositories   []Repository        `xml:"repository"               json:"repositories,omitempty"`
+}
+
+// GetProjectMeta retrieves the meta data of a project.
+func (c *Client) GetProjectMeta(project string) (*ProjectMeta, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/_meta", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta ProjectMeta
+	_, err = c.Do(req, &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &meta, nil
+}
+
+// MetaOptions records a comment in the history of project or package meta.
+type MetaOptions struct {
+	Comment string `url:"comment,omitempty"`
+}
+
+// SetProjectMeta replaces the meta data of a project, creating the
+// project if it doesn’t exist yet.
+func (c *Client) SetProjectMeta(meta *ProjectMeta) error {
+	return c.putProjectMeta(meta, "")
+}
+
+func (c *Client) putProjectMeta(meta *ProjectMeta, comment string) error {
+	req, err := c.NewRequest(http.MethodPut, "/source/"+meta.Name+"/_meta", MetaOptions{Comment: comment}, meta)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
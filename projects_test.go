@@ -0,0 +1,89 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Marshalling", func() {
+	When("project meta is unmarshalled and marshalled again", func() {
+		It("should preserve its contents", func() {
+			input := unindent(`
+				<project name="home:foo">
+					<title>Foo</title>
+					<description>Home of foo</description>
+					<person userid="foo" role="maintainer"></person>
+					<group groupid="bar" role="reviewer"></group>
+					<build><disable repository="Debian_11" arch="i586"></disable></build>
+					<publish><disable></disable></publish>
+					<repository name="Debian_11" rebuild="local">
+						<download arch="x86_64" url="http://deb.debian.org/debian" repotype="deb"><archfilter>x86_64</archfilter></download>
+						<path project="Debian:11" repository="main"></path>
+						<arch>x86_64</arch>
+						<arch>i586</arch>
+					</repository>
+				</project>`)
+			var meta ProjectMeta
+			err := xml.Unmarshal([]byte(input), &meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.Persons).To(Equal([]PersonRole{{"foo", "maintainer"}}))
+			Expect(meta.Repositories[0].Paths).To(Equal([]PathEntry{{"Debian:11", "main"}}))
+			data, err := xml.Marshal(meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		})
+	})
+
+	When("maintenance project meta is unmarshalled and marshalled again", func() {
+		It("should preserve the elements it doesn’t model", func() {
+			input := unindent(`
+				<project name="Foo:Maintenance" kind="maintenance">
+					<title>Maintenance of Foo</title>
+					<description></description>
+					<remoteproject>Foo:Maintenance</remoteproject>
+					<scmsync>https://git.example.com/foo/maintenance.git</scmsync>
+					<person userid="foo" role="maintainer"></person>
+					<build><disable></disable></build>
+					<maintenance><maintains project="Foo:1.0:Update"></maintains><maintains project="Foo:2.0:Update"></maintains></maintenance>
+					<frobnicate level="3"><widget>bar</widget></frobnicate>
+					<repository name="Foo_1.0">
+						<path project="Foo:1.0" repository="standard"></path>
+						<arch>x86_64</arch>
+					</repository>
+				</project>`)
+			var meta ProjectMeta
+			err := xml.Unmarshal([]byte(input), &meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.SCMSync).To(Equal("https://git.example.com/foo/maintenance.git"))
+			Expect(meta.Maintenance.Maintains).To(Equal([]MaintainedProject{{"Foo:1.0:Update"}, {"Foo:2.0:Update"}}))
+			data, err := xml.Marshal(meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		})
+	})
+
+	When("project meta with a devel project is marshalled", func() {
+		It("should refer to the devel project by the project attribute", func() {
+			input := unindent(`
+				<project name="Distro:Factory">
+					<title>Factory</title>
+					<description></description>
+					<devel project="Distro:Staging"></devel>
+				</project>`)
+			var meta ProjectMeta
+			err := xml.Unmarshal([]byte(input), &meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(meta.Devel).To(Equal(&DevelRef{Project: "Distro:Staging"}))
+			data, err := xml.Marshal(meta)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		})
+	})
+})
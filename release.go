@@ -0,0 +1,71 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+const (
+	commandRelease = "release"
+)
+
+// ReleaseOptions controls what is released and where to.
+// Without a target project and repository, builds are released into
+// the release targets defined in the repository meta.
+type ReleaseOptions struct {
+	Command          string `url:"cmd,omitempty"`
+	Repository       string `url:"repository,omitempty"`
+	Arch             string `url:"arch,omitempty"`
+	TargetProject    string `url:"target_project,omitempty"`
+	TargetRepository string `url:"target_repository,omitempty"`
+	SetRelease       string `url:"setrelease,omitempty"`
+	NoDelay          bool   `url:"nodelay,omitempty,int"`
+}
+
+func (c *Client) release(project, pkg string, opt ReleaseOptions) error {
+	opt.Command = commandRelease
+	req, err := c.NewRequest(http.MethodPost, sourcePath(project, pkg), opt, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseProject releases the builds of all packages of a project.
+func (c *Client) ReleaseProject(project string, opt ReleaseOptions) error {
+	return c.release(project, "", opt)
+}
+
+// ReleasePackage releases the builds of a package.
+func (c *Client) ReleasePackage(project, pkg string, opt ReleaseOptions) error {
+	return c.release(project, pkg, opt)
+}
+
+// GetReleaseTargets retrieves the release targets of the repositories
+// of a project, keyed by the repository name. Repositories without
+// release targets are left out.
+func (c *Client) GetReleaseTargets(project string) (map[string][]ReleaseTarget, error) {
+	meta, err := c.GetProjectMeta(project)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string][]ReleaseTarget)
+	for _, r := range meta.Repositories {
+		if len(r.ReleaseTargets) > 0 {
+			targets[r.Name] = r.ReleaseTargets
+		}
+	}
+
+	return targets, nil
+}
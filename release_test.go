@@ -0,0 +1,92 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Release", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a project is released into a target repository", func() {
+		It("should pass the options and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/foo:Staging", "cmd=release&nodelay=1&repository=images&setrelease=Build42&target_project=foo%3AProduct&target_repository=images"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.ReleaseProject("foo:Staging", ReleaseOptions{
+				Repository:       "images",
+				TargetProject:    "foo:Product",
+				TargetRepository: "images",
+				SetRelease:       "Build42",
+				NoDelay:          true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a package is released into its release targets", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/foo:Staging/bar", "cmd=release"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.ReleasePackage("foo:Staging", "bar", ReleaseOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("release targets of a project are requested", func() {
+		It("should return them per repository", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/foo:Staging/_meta"),
+					ghttp.RespondWith(http.StatusOK, `
+						<project name="foo:Staging">
+							<title/>
+							<description/>
+							<repository name="images">
+								<releasetarget project="foo:Product" repository="images" trigger="manual"/>
+								<path project="foo" repository="standard"/>
+								<arch>x86_64</arch>
+							</repository>
+							<repository name="standard">
+								<path project="foo" repository="standard"/>
+								<arch>x86_64</arch>
+							</repository>
+						</project>`),
+				),
+			)
+			targets, err := c.GetReleaseTargets("foo:Staging")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(targets).To(Equal(map[string][]ReleaseTarget{
+				"images": {{"foo:Product", "images", "manual"}},
+			}))
+		})
+	})
+})
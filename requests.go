@@ -0,0 +1,143 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+)
+
+const (
+	commandCreateRequest = "create"
+)
+
+// States of requests.
+const (
+	RequestStateNew        = "new"
+	RequestStateReview     = "review"
+	RequestStateDeclined   = "declined"
+	RequestStateAccepted   = "accepted"
+	RequestStateRevoked    = "revoked"
+	RequestStateSuperseded = "superseded"
+)
+
+// RequestSource is the source of a request action.
+type RequestSource struct {
+	Project string `xml:"project,attr"           json:"project"`
+	Package string `xml:"package,attr,omitempty" json:"package,omitempty"`
+	Rev     string `xml:"rev,attr,omitempty"     json:"rev,omitempty"`
+}
+
+// RequestTarget is the target of a request action.
+type RequestTarget struct {
+	Project        string `xml:"project,attr"                  json:"project"`
+	Package        string `xml:"package,attr,omitempty"        json:"package,omitempty"`
+	ReleaseProject string `xml:"releaseproject,attr,omitempty" json:"releaseproject,omitempty"`
+	Repository     string `xml:"repository,attr,omitempty"     json:"repository,omitempty"`
+}
+
+// RequestAction is a single action of a request,
+// e.g. submit, delete or maintenance_incident.
+type RequestAction struct {
+	Type   string         `xml:"type,attr"        json:"type"`
+	Source *RequestSource `xml:"source,omitempty" json:"source,omitempty"`
+	Target *RequestTarget `xml:"target,omitempty" json:"target,omitempty"`
+}
+
+// RequestState is the state of a request (new, review, accepted etc).
+type RequestState struct {
+	Name    string `xml:"name,attr"           json:"name"`
+	Who     string `xml:"who,attr,omitempty"  json:"who,omitempty"`
+	When    string `xml:"when,attr,omitempty" json:"when,omitempty"`
+	Comment string `xml:"comment,omitempty"   json:"comment,omitempty"`
+}
+
+// Request represents a request to change something in OBS,
+// e.g. to submit a package into a project.
+type Request struct {
+	XMLName     xml.Name        `xml:"request"                json:"-"`
+	ID          int             `xml:"id,attr,omitempty"      json:"id,omitempty"`
+	Creator     string          `xml:"creator,attr,omitempty" json:"creator,omitempty"`
+	Actions     []RequestAction `xml:"action"                 json:"actions"`
+	State       *RequestState   `xml:"state,omitempty"        json:"state,omitempty"`
+	Description string          `xml:"description,omitempty"  json:"description,omitempty"`
+}
+
+type RequestOptions struct {
+	Command string `url:"cmd,omitempty"`
+}
+
+// RequestListOptions filters the requests to list.
+// Roles select how the user, the group, the project or the package
+// are involved in the requests, e.g. as source, target or reviewer.
+type RequestListOptions struct {
+	User    string   `url:"user,omitempty"`
+	Group   string   `url:"group,omitempty"`
+	Project string   `url:"project,omitempty"`
+	Package string   `url:"package,omitempty"`
+	States  []string `url:"states,comma,omitempty"`
+	Types   []string `url:"types,comma,omitempty"`
+	Roles   []string `url:"roles,comma,omitempty"`
+}
+
+type requestCollectionOptions struct {
+	View string `url:"view"`
+	RequestListOptions
+}
+
+type requestCollection struct {
+	Requests []Request `xml:"request"`
+}
+
+// CreateRequest creates a new request and returns it as stored by OBS,
+// which includes its ID and state.
+func (c *Client) CreateRequest(r *Request) (*Request, error) {
+	req, err := c.NewRequest(http.MethodPost, "/request", RequestOptions{Command: commandCreateRequest}, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var created Request
+	_, err = c.Do(req, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetRequest retrieves a request by its ID.
+func (c *Client) GetRequest(id int) (*Request, error) {
+	req, err := c.NewRequest(http.MethodGet, "/request/"+strconv.Itoa(id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Request
+	_, err = c.Do(req, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// ListRequests retrieves the requests matching the options.
+func (c *Client) ListRequests(opt RequestListOptions) ([]Request, error) {
+	req, err := c.NewRequest(http.MethodGet, "/request", requestCollectionOptions{"collection", opt}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection requestCollection
+	_, err = c.Do(req, &collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Requests, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Requests", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a request is requested", func() {
+		It("should return the request and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/request/1234"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<request id="1234" creator="foo">
+							<action type="submit">
+								<source project="home:foo" package="bar" rev="5"/>
+								<target project="devel:bar" package="bar"/>
+							</action>
+							<state name="accepted" who="baz" when="2022-05-01T10:00:00">
+								<comment>Thanks</comment>
+							</state>
+							<description>Update to 1.2</description>
+						</request>`),
+				),
+			)
+			r, err := c.GetRequest(1234)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*r).To(Equal(Request{
+				XMLName: r.XMLName,
+				ID:      1234,
+				Creator: "foo",
+				Actions: []RequestAction{{
+					Type:   "submit",
+					Source: &RequestSource{"home:foo", "bar", "5"},
+					Target: &RequestTarget{Project: "devel:bar", Package: "bar"},
+				}},
+				State:       &RequestState{"accepted", "baz", "2022-05-01T10:00:00", "Thanks"},
+				Description: "Update to 1.2",
+			}))
+		})
+	})
+
+	When("open requests of a project are listed", func() {
+		It("should return them and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/request", "view=collection&project=devel%3Abar&states=new%2Creview"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<collection matches="2">
+							<request id="1234" creator="foo">
+								<action type="submit">
+									<source project="home:foo" package="bar" rev="5"/>
+									<target project="devel:bar" package="bar"/>
+								</action>
+								<state name="new" who="foo" when="2022-05-01T10:00:00"/>
+							</request>
+							<request id="1235" creator="baz">
+								<action type="delete">
+									<target project="devel:bar" package="quux"/>
+								</action>
+								<state name="review" who="baz" when="2022-05-02T10:00:00"/>
+							</request>
+						</collection>`),
+				),
+			)
+			rr, err := c.ListRequests(RequestListOptions{
+				Project: "devel:bar",
+				States:  []string{RequestStateNew, RequestStateReview},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rr).To(HaveLen(2))
+			Expect(rr[0].ID).To(Equal(1234))
+			Expect(rr[1].ID).To(Equal(1235))
+			Expect(rr[1].State.Name).To(Equal(RequestStateReview))
+			Expect(rr[1].Actions[0].Target).To(Equal(&RequestTarget{Project: "devel:bar", Package: "quux"}))
+		})
+	})
+})
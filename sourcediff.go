@@ -0,0 +1,195 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	commandDiff = "diff"
+)
+
+// SourceDiffOptions selects the revisions to compare.
+// By default, the latest revision of a package is compared with the one
+// before it; OldProject, OldPackage and OldRev select another base.
+type SourceDiffOptions struct {
+	Command    string `url:"cmd,omitempty"`
+	Rev        string `url:"rev,omitempty"`
+	OldProject string `url:"oproject,omitempty"`
+	OldPackage string `url:"opackage,omitempty"`
+	OldRev     string `url:"orev,omitempty"`
+	LinkRev    string `url:"linkrev,omitempty"`
+	Unified    bool   `url:"unified,omitempty,int"`
+	Expand     bool   `url:"expand,omitempty,int"`
+	View       string `url:"view,omitempty"`
+}
+
+// SourceDiffRevision identifies one side of a diff.
+type SourceDiffRevision struct {
+	Project string `xml:"project,attr" json:"project"`
+	Package string `xml:"package,attr" json:"package"`
+	Rev     string `xml:"rev,attr"     json:"rev"`
+	SrcMD5  string `xml:"srcmd5,attr"  json:"srcmd5"`
+}
+
+// SourceDiffFileInfo describes a file on one side of a diff.
+type SourceDiffFileInfo struct {
+	Name string `xml:"name,attr" json:"name"`
+	MD5  string `xml:"md5,attr"  json:"md5"`
+	Size int64  `xml:"size,attr" json:"size"`
+}
+
+// DiffHunk is a hunk of a unified diff.
+// Lines keep their leading ' ', '+' or '-'.
+type DiffHunk struct {
+	OldStart int      `json:"old_start"`
+	OldLines int      `json:"old_lines"`
+	NewStart int      `json:"new_start"`
+	NewLines int      `json:"new_lines"`
+	Section  string   `json:"section,omitempty"`
+	Lines    []string `json:"lines"`
+}
+
+// SourceDiffFile is a file added, deleted or changed between the revisions.
+type SourceDiffFile struct {
+	State string              `xml:"state,attr"    json:"state"`
+	Old   *SourceDiffFileInfo `xml:"old,omitempty" json:"old,omitempty"`
+	New   *SourceDiffFileInfo `xml:"new,omitempty" json:"new,omitempty"`
+	Diff  string              `xml:"diff"          json:"-"`
+	Hunks []DiffHunk          `xml:"-"             json:"hunks,omitempty"`
+}
+
+// SourceDiffIssue is an issue added or removed in the changes files.
+type SourceDiffIssue struct {
+	State   string `xml:"state,attr"   json:"state"`
+	Tracker string `xml:"tracker,attr" json:"tracker"`
+	Name    string `xml:"name,attr"    json:"name"`
+}
+
+// SourceDiff is a structured diff between two revisions of sources.
+type SourceDiff struct {
+	XMLName xml.Name           `xml:"sourcediff"   json:"-"`
+	Key     string             `xml:"key,attr"     json:"key,omitempty"`
+	Old     SourceDiffRevision `xml:"old"          json:"old"`
+	New     SourceDiffRevision `xml:"new"          json:"new"`
+	Files   []SourceDiffFile   `xml:"files>file"   json:"files"`
+	Issues  []SourceDiffIssue  `xml:"issues>issue" json:"issues,omitempty"`
+}
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// ParseHunks splits the text of a unified diff into hunks.
+// Anything outside hunks, e.g. the file headers or notes about
+// truncated diffs, is ignored.
+func ParseHunks(diff string) []DiffHunk {
+	var (
+		hunks            []DiffHunk
+		oldLeft, newLeft int
+		lastHunk         *DiffHunk
+	)
+
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		if m := hunkHeaderRE.FindStringSubmatch(line); m != nil {
+			hunks = append(hunks, DiffHunk{
+				OldStart: atoiDefault(m[1], 0),
+				OldLines: atoiDefault(m[2], 1),
+				NewStart: atoiDefault(m[3], 0),
+				NewLines: atoiDefault(m[4], 1),
+				Section:  m[5],
+			})
+			lastHunk = &hunks[len(hunks)-1]
+			oldLeft, newLeft = lastHunk.OldLines, lastHunk.NewLines
+			continue
+		}
+
+		if lastHunk == nil {
+			continue
+		}
+
+		// “\ No newline at end of file” may follow the last line of a hunk
+		if strings.HasPrefix(line, "\\") {
+			lastHunk.Lines = append(lastHunk.Lines, line)
+			continue
+		}
+
+		if oldLeft == 0 && newLeft == 0 {
+			lastHunk = nil
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "-"):
+			oldLeft--
+		case strings.HasPrefix(line, "+"):
+			newLeft--
+		case strings.HasPrefix(line, " "), line == "":
+			oldLeft--
+			newLeft--
+		default:
+			// Not a diff line: the hunk has been truncated
+			lastHunk, oldLeft, newLeft = nil, 0, 0
+			continue
+		}
+
+		lastHunk.Lines = append(lastHunk.Lines, line)
+	}
+
+	return hunks
+}
+
+// GetSourceDiff retrieves the diff of the sources of a package as text.
+func (c *Client) GetSourceDiff(project, pkg string, opt SourceDiffOptions) (string, error) {
+	opt.Command = commandDiff
+	req, err := c.NewRequest(http.MethodPost, "/source/"+project+"/"+pkg, opt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	_, err = c.Do(req, &diff)
+	if err != nil {
+		return "", err
+	}
+
+	return diff.String(), nil
+}
+
+// GetStructuredSourceDiff retrieves the diff of the sources of a package
+// and splits the diff of each file into hunks.
+func (c *Client) GetStructuredSourceDiff(project, pkg string, opt SourceDiffOptions) (*SourceDiff, error) {
+	opt.Command = commandDiff
+	opt.View = "xml"
+	req, err := c.NewRequest(http.MethodPost, "/source/"+project+"/"+pkg, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var diff SourceDiff
+	_, err = c.Do(req, &diff)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range diff.Files {
+		diff.Files[i].Hunks = ParseHunks(diff.Files[i].Diff)
+	}
+
+	return &diff, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const unifiedDiff = `--- bar.spec
++++ bar.spec
+@@ -1,3 +1,3 @@ Name: bar
+ Name: bar
+-Version: 1.0
++Version: 1.1
+ Release: 0
+@@ -10 +10,2 @@
+-%files
++%files
++%license COPYING
+\ No newline at end of file
+--- bar.changes
++++ bar.changes
+@@ -0,0 +1 @@
++- Update to 1.1
+`
+
+var _ = Describe("Source diffs", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a unified diff is split into hunks", func() {
+		It("should skip the file headers", func() {
+			hunks := ParseHunks(unifiedDiff)
+			Expect(hunks).To(Equal([]DiffHunk{
+				{1, 3, 1, 3, "Name: bar", []string{" Name: bar", "-Version: 1.0", "+Version: 1.1", " Release: 0"}},
+				{10, 1, 10, 2, "", []string{"-%files", "+%files", "+%license COPYING", `\ No newline at end of file`}},
+				{0, 0, 1, 1, "", []string{"+- Update to 1.1"}},
+			}))
+		})
+	})
+
+	When("a diff is requested as text", func() {
+		It("should return the diff and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/home:foo/bar", "cmd=diff&expand=1&orev=3&unified=1"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, unifiedDiff),
+				),
+			)
+			diff, err := c.GetSourceDiff("home:foo", "bar", SourceDiffOptions{
+				OldRev:  "3",
+				Unified: true,
+				Expand:  true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(Equal(unifiedDiff))
+		})
+	})
+
+	When("a structured diff is requested", func() {
+		It("should return the files with their hunks", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/source/home:foo/bar", "cmd=diff&opackage=bar&oproject=devel%3Abar&view=xml"),
+					ghttp.RespondWith(http.StatusOK, `
+						<sourcediff key="0123">
+							<old project="devel:bar" package="bar" rev="7" srcmd5="aaaa"/>
+							<new project="home:foo" package="bar" rev="2" srcmd5="bbbb"/>
+							<files>
+								<file state="changed">
+									<old name="bar.spec" md5="cccc" size="120"/>
+									<new name="bar.spec" md5="dddd" size="121"/>
+									<diff lines="4">@@ -2 +2 @@
+-Version: 1.0
++Version: 1.1
+</diff>
+								</file>
+								<file state="deleted">
+									<old name="bar.patch" md5="eeee" size="300"/>
+									<diff lines="0"/>
+								</file>
+							</files>
+							<issues>
+								<issue state="added" tracker="cve" name="CVE-2022-1234"/>
+							</issues>
+						</sourcediff>`),
+				),
+			)
+			diff, err := c.GetStructuredSourceDiff("home:foo", "bar", SourceDiffOptions{
+				OldProject: "devel:bar",
+				OldPackage: "bar",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff.Old.Rev).To(Equal("7"))
+			Expect(diff.New.SrcMD5).To(Equal("bbbb"))
+			Expect(diff.Files).To(HaveLen(2))
+			Expect(diff.Files[0].New.Size).To(Equal(int64(121)))
+			Expect(diff.Files[0].Hunks).To(Equal([]DiffHunk{
+				{2, 1, 2, 1, "", []string{"-Version: 1.0", "+Version: 1.1"}},
+			}))
+			Expect(diff.Files[1].State).To(Equal("deleted"))
+			Expect(diff.Files[1].New).To(BeNil())
+			Expect(diff.Files[1].Hunks).To(BeEmpty())
+			Expect(diff.Issues).To(Equal([]SourceDiffIssue{{"added", "cve", "CVE-2022-1234"}}))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+)
+
+// SourceEntry is a file in the sources of a package.
+type SourceEntry struct {
+	Name  string `xml:"name,attr"  json:"name"`
+	MD5   string `xml:"md5,attr"   json:"md5"`
+	Size  int64  `xml:"size,attr"  json:"size"`
+	MTime int64  `xml:"mtime,attr" json:"mtime"`
+}
+
+// SourceDirectory represents a revision of the sources of a package.
+// If the package is a link, LinkInfo describes the link.
+type SourceDirectory struct {
+	XMLName  xml.Name      `xml:"directory"          json:"-"`
+	Name     string        `xml:"name,attr"          json:"name"`
+	Rev      string        `xml:"rev,attr"           json:"rev"`
+	VRev     string        `xml:"vrev,attr"          json:"vrev,omitempty"`
+	SrcMD5   string        `xml:"srcmd5,attr"        json:"srcmd5"`
+	LinkInfo *LinkInfo     `xml:"linkinfo,omitempty" json:"linkinfo,omitempty"`
+	Entries  []SourceEntry `xml:"entry"              json:"entries"`
+}
+
+// SourceInfo is the current revision of the sources of a package.
+type SourceInfo struct {
+	Package   string `xml:"package,attr"             json:"package"`
+	Rev       string `xml:"rev,attr"                 json:"rev"`
+	VRev      string `xml:"vrev,attr"                json:"vrev,omitempty"`
+	SrcMD5    string `xml:"srcmd5,attr"              json:"srcmd5"`
+	VerifyMD5 string `xml:"verifymd5,attr,omitempty" json:"verifymd5,omitempty"`
+	Error     string `xml:"error,omitempty"          json:"error,omitempty"`
+}
+
+type sourceInfoList struct {
+	SourceInfos []SourceInfo `xml:"sourceinfo"`
+}
+
+type sourceInfoOptions struct {
+	View       string `url:"view"`
+	NoFilename bool   `url:"nofilename,int"`
+}
+
+// SourceOptions selects a revision of the sources.
+// For links, Expand requests the sources with the link applied,
+// optionally against the revision LinkRev of the link target.
+type SourceOptions struct {
+	Rev     string `url:"rev,omitempty"`
+	Expand  bool   `url:"expand,omitempty,int"`
+	LinkRev string `url:"linkrev,omitempty"`
+}
+
+// ListPackages gets a list of names of all packages in a project.
+func (c *Client) ListPackages(project string) ([]string, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dir directory
+	_, err = c.Do(req, &dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []string
+	for _, p := range dir.Entries {
+		packages = append(packages, p.Name)
+	}
+
+	return packages, nil
+}
+
+// GetSourceInfo retrieves the current revisions of the sources of all
+// packages in a project.
+func (c *Client) GetSourceInfo(project string) ([]SourceInfo, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project, sourceInfoOptions{"info", true}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list sourceInfoList
+	_, err = c.Do(req, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.SourceInfos, nil
+}
+
+// ListSourceFiles retrieves the list of files in the sources of a package.
+func (c *Client) ListSourceFiles(project, pkg string, opt SourceOptions) (*SourceDirectory, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dir SourceDirectory
+	_, err = c.Do(req, &dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dir, nil
+}
+
+// GetSourceFile retrieves the contents of a file in the sources of a package.
+func (c *Client) GetSourceFile(project, pkg, filename string, opt SourceOptions) ([]byte, error) {
+	req, err := c.NewRequest(http.MethodGet, "/source/"+project+"/"+pkg+"/"+filename, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	_, err = c.Do(req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PutSourceFile replaces a file in the sources of a package,
+// creating a new revision.
+func (c *Client) PutSourceFile(project, pkg, filename string, data []byte) error {
+	req, err := c.NewRequest(http.MethodPut, "/source/"+project+"/"+pkg+"/"+filename, nil, string(data))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Sources", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("packages of a project are listed", func() {
+		It("should return their names and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory count="2">
+							<entry name="bar"/>
+							<entry name="baz"/>
+						</directory>`),
+				),
+			)
+			pp, err := c.ListPackages("home:foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pp).To(Equal([]string{"bar", "baz"}))
+		})
+	})
+
+	When("source info of a project is requested", func() {
+		It("should return the revisions of the packages", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo", "view=info&nofilename=1"),
+					ghttp.RespondWith(http.StatusOK, `
+						<sourceinfolist>
+							<sourceinfo package="bar" rev="5" vrev="5" srcmd5="ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8" verifymd5="ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8"/>
+							<sourceinfo package="baz" rev="2" vrev="2" srcmd5="0d5c3e3f16a1e1bde5b1ec6ec8b9d7f1">
+								<error>bad build configuration, no build type defined or detected</error>
+							</sourceinfo>
+						</sourceinfolist>`),
+				),
+			)
+			ii, err := c.GetSourceInfo("home:foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ii).To(Equal([]SourceInfo{
+				{"bar", "5", "5", "ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8", "ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8", ""},
+				{"baz", "2", "2", "0d5c3e3f16a1e1bde5b1ec6ec8b9d7f1", "", "bad build configuration, no build type defined or detected"},
+			}))
+		})
+	})
+
+	When("expanded sources of a linked package are listed", func() {
+		It("should return the files and the link info", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/bar", "expand=1"),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory name="bar" rev="aaaa" vrev="3" srcmd5="aaaa">
+							<linkinfo project="devel:bar" package="bar" srcmd5="bbbb" baserev="cccc" lsrcmd5="dddd"/>
+							<entry name="bar.spec" md5="eeee" size="1234" mtime="1651399200"/>
+						</directory>`),
+				),
+			)
+			dir, err := c.ListSourceFiles("home:foo", "bar", SourceOptions{Expand: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dir.LinkInfo).To(Equal(&LinkInfo{
+				Project: "devel:bar",
+				Package: "bar",
+				SrcMD5:  "bbbb",
+				BaseRev: "cccc",
+				LSrcMD5: "dddd",
+			}))
+			Expect(dir.LinkInfo.IsBroken()).To(BeFalse())
+			Expect(dir.Entries).To(Equal([]SourceEntry{{"bar.spec", "eeee", 1234, 1651399200}}))
+		})
+	})
+
+	When("a source file is requested", func() {
+		It("should return its contents", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/source/home:foo/bar/bar.spec", "rev=3"),
+					ghttp.RespondWith(http.StatusOK, "Name: bar\n"),
+				),
+			)
+			data, err := c.GetSourceFile("home:foo", "bar", "bar.spec", SourceOptions{Rev: "3"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("Name: bar\n"))
+		})
+	})
+})
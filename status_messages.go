@@ -0,0 +1,97 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+)
+
+// Severities of status messages.
+const (
+	SeverityInformation  = "information"
+	SeverityGreen        = "green"
+	SeverityYellow       = "yellow"
+	SeverityRed          = "red"
+	SeverityAnnouncement = "announcement"
+)
+
+// Communication scopes of status messages, i.e. who gets to see them.
+const (
+	ScopeAllUsers       = "all_users"
+	ScopeLoggedInUsers  = "logged_in_users"
+	ScopeAdminUsers     = "admin_users"
+	ScopeInRolloutUsers = "in_rollout_users"
+)
+
+// StatusMessage is a site-wide message shown to the users of OBS,
+// e.g. a notice about upcoming maintenance.
+type StatusMessage struct {
+	XMLName   xml.Name `xml:"status_message"       json:"-"`
+	ID        int      `xml:"id,attr,omitempty"    json:"id,omitempty"`
+	Message   string   `xml:"message"              json:"message"`
+	User      string   `xml:"user,omitempty"       json:"user,omitempty"`
+	Severity  string   `xml:"severity,omitempty"   json:"severity,omitempty"`
+	Scope     string   `xml:"scope,omitempty"      json:"scope,omitempty"`
+	CreatedAt string   `xml:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+type statusMessages struct {
+	Messages []StatusMessage `xml:"status_message"`
+}
+
+type StatusMessageOptions struct {
+	Limit int `url:"limit,omitempty"`
+}
+
+// ListStatusMessages retrieves the current status messages, newest
+// first. If limit is not zero, at most limit messages are returned.
+func (c *Client) ListStatusMessages(limit int) ([]StatusMessage, error) {
+	req, err := c.NewRequest(http.MethodGet, "/status_messages", StatusMessageOptions{Limit: limit}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages statusMessages
+	_, err = c.Do(req, &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	return messages.Messages, nil
+}
+
+// CreateStatusMessage posts a new status message.
+// Only administrators can post status messages.
+func (c *Client) CreateStatusMessage(m *StatusMessage) error {
+	req, err := c.NewRequest(http.MethodPost, "/status_messages", nil, m)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteStatusMessage deletes a status message.
+func (c *Client) DeleteStatusMessage(id int) error {
+	req, err := c.NewRequest(http.MethodDelete, "/status_messages/"+strconv.Itoa(id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
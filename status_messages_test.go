@@ -0,0 +1,89 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Status messages", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("status messages are listed", func() {
+		It("should return the messages", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/status_messages", "limit=5"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<status_messages count="1">
+							<status_message id="7">
+								<message>Upgrade to OBS 2.10 tonight</message>
+								<user>admin</user>
+								<severity>announcement</severity>
+								<scope>all_users</scope>
+								<created_at>2022-05-01 10:00:00 UTC</created_at>
+							</status_message>
+						</status_messages>`),
+				),
+			)
+			mm, err := c.ListStatusMessages(5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mm).To(HaveLen(1))
+			Expect(mm[0].ID).To(Equal(7))
+			Expect(mm[0].Message).To(Equal("Upgrade to OBS 2.10 tonight"))
+			Expect(mm[0].Severity).To(Equal(SeverityAnnouncement))
+			Expect(mm[0].Scope).To(Equal(ScopeAllUsers))
+		})
+	})
+
+	When("a status message is posted", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/status_messages"),
+					ghttp.VerifyBody([]byte(`<status_message><message>Down for maintenance</message><severity>red</severity><scope>logged_in_users</scope></status_message>`)),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.CreateStatusMessage(&StatusMessage{
+				Message:  "Down for maintenance",
+				Severity: SeverityRed,
+				Scope:    ScopeLoggedInUsers,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a status message is deleted", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, "/status_messages/7"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok"/>`),
+				),
+			)
+			err := c.DeleteStatusMessage(7)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,119 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	commandCreateToken = "create"
+)
+
+// TokenKind is the operation a personal API token can be used for.
+type TokenKind string
+
+const (
+	TokenRunService TokenKind = "runservice"
+	TokenRebuild    TokenKind = "rebuild"
+	TokenRelease    TokenKind = "release"
+	TokenWorkflow   TokenKind = "workflow"
+)
+
+// Token represents a personal API token of a user.
+// A token may be scoped to a single package, in which case it can
+// only be used to trigger operations on that package.
+type Token struct {
+	XMLName     xml.Name  `xml:"entry"                  json:"-"`
+	ID          int       `xml:"id,attr"                json:"id"`
+	String      string    `xml:"string,attr"            json:"string"`
+	Kind        TokenKind `xml:"kind,attr"              json:"kind"`
+	Description string    `xml:"description,attr"       json:"description,omitempty"`
+	TriggeredAt string    `xml:"triggered_at,attr"      json:"triggered_at,omitempty"`
+	Project     string    `xml:"project,attr,omitempty" json:"project,omitempty"`
+	Package     string    `xml:"package,attr,omitempty" json:"package,omitempty"`
+}
+
+type TokenOptions struct {
+	Command     string    `url:"cmd,omitempty"`
+	Kind        TokenKind `url:"operation,omitempty"`
+	Project     string    `url:"project,omitempty"`
+	Package     string    `url:"package,omitempty"`
+	Description string    `url:"description,omitempty"`
+	SCMToken    string    `url:"scm_token,omitempty"`
+}
+
+type tokenDirectory struct {
+	Tokens []Token `xml:"entry"`
+}
+
+// ListTokens retrieves the personal API tokens of the user.
+func (c *Client) ListTokens(user string) ([]Token, error) {
+	req, err := c.NewRequest(http.MethodGet, "/person/"+user+"/token", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dir tokenDirectory
+	_, err = c.Do(req, &dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return dir.Tokens, nil
+}
+
+// CreateToken creates a new personal API token for the user.
+// The kind of the token, its scope and description are taken from opt;
+// the SCM token is only relevant to workflow tokens.
+func (c *Client) CreateToken(user string, opt TokenOptions) (*Token, error) {
+	opt.Command = commandCreateToken
+	req, err := c.NewRequest(http.MethodPost, "/person/"+user+"/token", opt, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var s status
+	_, err = c.Do(req, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	t := Token{
+		String:      s.data("token"),
+		Kind:        opt.Kind,
+		Description: opt.Description,
+		Project:     opt.Project,
+		Package:     opt.Package,
+	}
+
+	if id := s.data("id"); id != "" {
+		t.ID, err = strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse token ID '%s': %w", id, err)
+		}
+	}
+
+	return &t, nil
+}
+
+// DeleteToken deletes a personal API token of the user.
+func (c *Client) DeleteToken(user string, id int) error {
+	req, err := c.NewRequest(http.MethodDelete, "/person/"+user+"/token/"+strconv.Itoa(id), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
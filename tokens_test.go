@@ -0,0 +1,124 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Tokens", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("tokens of a user are listed", func() {
+		It("should return the tokens and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/person/foo/token"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<directory count="2">
+							<entry id="1" string="abc" kind="runservice" description="" triggered_at="" project="home:foo" package="bar"/>
+							<entry id="2" string="def" kind="workflow" description="CI" triggered_at="2022-05-01 10:00:00 UTC"/>
+						</directory>`),
+				),
+			)
+			tt, err := c.ListTokens("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tt).To(HaveLen(2))
+			Expect(tt[0].ID).To(Equal(1))
+			Expect(tt[0].String).To(Equal("abc"))
+			Expect(tt[0].Kind).To(Equal(TokenRunService))
+			Expect(tt[0].Project).To(Equal("home:foo"))
+			Expect(tt[0].Package).To(Equal("bar"))
+			Expect(tt[1].Kind).To(Equal(TokenWorkflow))
+			Expect(tt[1].Description).To(Equal("CI"))
+		})
+	})
+
+	When("a new scoped token is being created", func() {
+		It("should return the token and no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/person/foo/token", "cmd=create&description=hook&operation=rebuild&package=bar&project=home%3Afoo"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `
+						<status code="ok">
+							<summary>Ok</summary>
+							<data name="token">s3cr3t</data>
+							<data name="id">42</data>
+						</status>`),
+				),
+			)
+			t, err := c.CreateToken("foo", TokenOptions{
+				Kind:        TokenRebuild,
+				Project:     "home:foo",
+				Package:     "bar",
+				Description: "hook",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*t).To(Equal(Token{
+				ID:          42,
+				String:      "s3cr3t",
+				Kind:        TokenRebuild,
+				Description: "hook",
+				Project:     "home:foo",
+				Package:     "bar",
+			}))
+		})
+	})
+
+	When("a token is being deleted", func() {
+		It("should return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, "/person/foo/token/42"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok">
+							<summary>Ok</summary>
+						</status>`),
+				),
+			)
+			err := c.DeleteToken("foo", 42)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a client authenticates with a token", func() {
+		It("should send the token in the authorization header", func() {
+			tc, err := NewTokenClient("s3cr3t", WithBaseURL(server.URL()))
+			Expect(err).ToNot(HaveOccurred())
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/trigger/runservice"),
+					ghttp.VerifyHeaderKV("Authorization", "Token s3cr3t"),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok">
+							<summary>Ok</summary>
+						</status>`),
+				),
+			)
+			req, err := tc.NewRequest(http.MethodPost, "/trigger/runservice", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = tc.Do(req, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
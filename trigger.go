@@ -0,0 +1,82 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+)
+
+// TriggerOptions selects what a trigger call operates on.
+// When the token used is scoped to a package, Project and Package
+// may be left empty.
+type TriggerOptions struct {
+	Project          string `url:"project,omitempty"`
+	Package          string `url:"package,omitempty"`
+	Repository       string `url:"repository,omitempty"`
+	Arch             string `url:"arch,omitempty"`
+	TargetProject    string `url:"targetproject,omitempty"`
+	TargetRepository string `url:"targetrepository,omitempty"`
+}
+
+type workflowOptions struct {
+	ID int `url:"id,omitempty"`
+}
+
+func (c *Client) trigger(kind TokenKind, opt TriggerOptions) error {
+	req, err := c.NewRequest(http.MethodPost, "/trigger/"+string(kind), opt, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TriggerRunService runs the source services of a package.
+// This requires a client authenticated with a runservice token.
+func (c *Client) TriggerRunService(opt TriggerOptions) error {
+	return c.trigger(TokenRunService, opt)
+}
+
+// TriggerRebuild rebuilds a package, optionally only in the given
+// repository and architecture.
+// This requires a client authenticated with a rebuild token.
+func (c *Client) TriggerRebuild(opt TriggerOptions) error {
+	return c.trigger(TokenRebuild, opt)
+}
+
+// TriggerRelease releases a package into the release targets of its
+// repositories, or into the given target project and repository.
+// This requires a client authenticated with a release token.
+func (c *Client) TriggerRelease(opt TriggerOptions) error {
+	return c.trigger(TokenRelease, opt)
+}
+
+// TriggerWorkflow passes an SCM webhook payload on to OBS to run the
+// workflows of the token with the given ID. The SCM-specific webhook
+// headers (e.g. X-GitHub-Event) must be passed in header as received.
+// This requires a client authenticated with a workflow token.
+func (c *Client) TriggerWorkflow(id int, header http.Header, payload []byte) error {
+	req, err := c.NewRequest(http.MethodPost, "/trigger/"+string(TokenWorkflow), workflowOptions{ID: id}, string(payload))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	_, err = c.Do(req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Triggers", func() {
+	const token = "s3cr3t"
+
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewTokenClient(token, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("a rebuild is triggered", func() {
+		It("should pass the parameters and return no error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/trigger/rebuild", "arch=x86_64&package=bar&project=home%3Afoo&repository=Debian_11"),
+					ghttp.VerifyHeaderKV("Authorization", "Token "+token),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok">
+							<summary>Ok</summary>
+						</status>`),
+				),
+			)
+			err := c.TriggerRebuild(TriggerOptions{
+				Project:    "home:foo",
+				Package:    "bar",
+				Repository: "Debian_11",
+				Arch:       "x86_64",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("a release is triggered with an invalid token", func() {
+		It("should return an error", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/trigger/release", "targetproject=foo%3Aproduct"),
+					ghttp.RespondWith(http.StatusForbidden, `
+						<status code="invalid_token">
+							<summary>No valid token found</summary>
+						</status>`),
+				),
+			)
+			err := c.TriggerRelease(TriggerOptions{TargetProject: "foo:product"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HaveSuffix("No valid token found"))
+		})
+	})
+
+	When("a workflow is triggered", func() {
+		It("should pass the webhook headers and payload", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, "/trigger/workflow", "id=7"),
+					ghttp.VerifyHeaderKV("Authorization", "Token "+token),
+					ghttp.VerifyHeaderKV("X-GitHub-Event", "push"),
+					ghttp.VerifyBody([]byte(`{"ref":"refs/heads/main"}`)),
+					ghttp.RespondWith(http.StatusOK, `<status code="ok">
+							<summary>Ok</summary>
+						</status>`),
+				),
+			)
+			header := http.Header{}
+			header.Set("X-GitHub-Event", "push")
+			err := c.TriggerWorkflow(7, header, []byte(`{"ref":"refs/heads/main"}`))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,360 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of changes reported by Watcher.
+const (
+	ChangeBuildStatus  = "build_status"
+	ChangeCommit       = "commit"
+	ChangeNewRequest   = "new_request"
+	ChangeRequestState = "request_state"
+)
+
+// DefaultWatchInterval is how often a Watcher polls by default.
+const DefaultWatchInterval = 5 * time.Minute
+
+// openRequestStates are the states of requests Watcher keeps track of.
+var openRequestStates = []string{RequestStateNew, RequestStateReview, RequestStateDeclined}
+
+// Change is a change noticed by Watcher. Old and New are the build
+// status codes, the revisions or the request states before and after
+// the change; Old is empty for things not seen before, New is empty
+// for requests which no longer exist.
+type Change struct {
+	Kind       string   `json:"kind"`
+	Project    string   `json:"project"`
+	Package    string   `json:"package,omitempty"`
+	Repository string   `json:"repository,omitempty"`
+	Arch       string   `json:"arch,omitempty"`
+	RequestID  int      `json:"request_id,omitempty"`
+	Request    *Request `json:"request,omitempty"`
+	Old        string   `json:"old,omitempty"`
+	New        string   `json:"new"`
+}
+
+// ProjectState is what Watcher last saw of a project.
+type ProjectState struct {
+	// Builds maps repository/arch/package to the build status code.
+	Builds map[string]string `json:"builds"`
+	// Revisions maps packages to their revisions.
+	Revisions map[string]string `json:"revisions"`
+	// Requests maps IDs of open requests to their states.
+	Requests map[int]string `json:"requests"`
+}
+
+// WatcherState is what Watcher last saw of the projects it watches.
+type WatcherState struct {
+	Projects map[string]*ProjectState `json:"projects"`
+}
+
+// Watcher polls projects for changes of build results, revisions of
+// packages and requests. Changes are only reported against the state
+// seen before, so the first poll of a project reports nothing.
+//
+// If StateFile is set, the state is loaded from it when the watcher
+// starts and saved to it after every poll.
+//
+// Polling a project may fail, e.g. when OBS is temporarily unavailable.
+// The error is then passed to OnError if it is set, and the project is
+// polled again next time.
+type Watcher struct {
+	Client    *Client
+	Projects  []string
+	Interval  time.Duration
+	StateFile string
+	OnError   func(project string, err error)
+
+	state WatcherState
+}
+
+// NewWatcher creates a watcher for the projects polling every
+// DefaultWatchInterval, which is also used when Interval is not set.
+func NewWatcher(c *Client, projects ...string) *Watcher {
+	return &Watcher{
+		Client:   c,
+		Projects: projects,
+		Interval: DefaultWatchInterval,
+		state: WatcherState{
+			Projects: make(map[string]*ProjectState),
+		},
+	}
+}
+
+// State returns the state last seen by the watcher.
+func (w *Watcher) State() *WatcherState {
+	return &w.state
+}
+
+// LoadState loads the state from StateFile, if it exists.
+func (w *Watcher) LoadState() error {
+	data, err := os.ReadFile(w.StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var state WatcherState
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return err
+	}
+	w.state = state
+
+	return nil
+}
+
+// SaveState saves the state to StateFile, replacing it atomically.
+func (w *Watcher) SaveState() error {
+	data, err := json.MarshalIndent(&w.state, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(w.StateFile), filepath.Base(w.StateFile)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), w.StateFile)
+}
+
+// Poll polls all projects once and passes the changes since the last
+// poll to the handler. The state of a project only advances once the
+// handler has accepted all of its changes; if the handler fails, Poll
+// stops and returns the error, and the changes of that project are
+// reported again by the next poll.
+func (w *Watcher) Poll(handler func(Change) error) error {
+	if w.state.Projects == nil {
+		w.state.Projects = make(map[string]*ProjectState)
+	}
+
+	for _, project := range w.Projects {
+		state, changes, err := w.pollProject(project, w.state.Projects[project])
+		if err != nil {
+			if w.OnError != nil {
+				w.OnError(project, err)
+			}
+			continue
+		}
+
+		for _, change := range changes {
+			err = handler(change)
+			if err != nil {
+				return err
+			}
+		}
+		w.state.Projects[project] = state
+	}
+
+	return nil
+}
+
+// Run polls the projects every Interval and passes the changes to the
+// handler until the context is done or the handler fails. Changes not
+// accepted by the handler are reported again by the next poll, also
+// after a restart if StateFile is set.
+func (w *Watcher) Run(ctx context.Context, handler func(Change) error) error {
+	if w.StateFile != "" {
+		err := w.LoadState()
+		if err != nil {
+			return err
+		}
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Poll(handler)
+
+		if w.StateFile != "" {
+			saveErr := w.SaveState()
+			if err == nil {
+				err = saveErr
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) pollProject(project string, old *ProjectState) (*ProjectState, []Change, error) {
+	state := &ProjectState{
+		Builds:    make(map[string]string),
+		Revisions: make(map[string]string),
+		Requests:  make(map[int]string),
+	}
+
+	results, err := w.Client.GetBuildResults(project, BuildResultOptions{MultiBuild: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, result := range results {
+		for _, status := range result.Statuses {
+			state.Builds[result.Repository+"/"+result.Arch+"/"+status.Package] = status.Code
+		}
+	}
+
+	infos, err := w.Client.GetSourceInfo(project)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, info := range infos {
+		state.Revisions[info.Package] = info.Rev
+	}
+
+	requests, err := w.Client.ListRequests(RequestListOptions{
+		Project: project,
+		States:  openRequestStates,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	open := make(map[int]*Request)
+	for i := range requests {
+		r := &requests[i]
+		if r.State != nil {
+			state.Requests[r.ID] = r.State.Name
+		}
+		open[r.ID] = r
+	}
+
+	if old == nil {
+		return state, nil, nil
+	}
+
+	var changes []Change
+	for _, key := range sortedKeys(state.Builds) {
+		if state.Builds[key] == old.Builds[key] {
+			continue
+		}
+		parts := strings.SplitN(key, "/", 3)
+		changes = append(changes, Change{
+			Kind:       ChangeBuildStatus,
+			Project:    project,
+			Repository: parts[0],
+			Arch:       parts[1],
+			Package:    parts[2],
+			Old:        old.Builds[key],
+			New:        state.Builds[key],
+		})
+	}
+
+	for _, pkg := range sortedKeys(state.Revisions) {
+		if state.Revisions[pkg] == old.Revisions[pkg] {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:    ChangeCommit,
+			Project: project,
+			Package: pkg,
+			Old:     old.Revisions[pkg],
+			New:     state.Revisions[pkg],
+		})
+	}
+
+	for _, id := range sortedRequestIDs(state.Requests) {
+		oldState, seen := old.Requests[id]
+		if seen && oldState == state.Requests[id] {
+			continue
+		}
+		kind := ChangeRequestState
+		if !seen {
+			kind = ChangeNewRequest
+		}
+		changes = append(changes, Change{
+			Kind:      kind,
+			Project:   project,
+			RequestID: id,
+			Request:   open[id],
+			Old:       oldState,
+			New:       state.Requests[id],
+		})
+	}
+
+	// Requests no longer open have been accepted, revoked etc.
+	for _, id := range sortedRequestIDs(old.Requests) {
+		if _, ok := state.Requests[id]; ok {
+			continue
+		}
+		r, err := w.Client.GetRequest(id)
+		if isNotFound(err) {
+			r = nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		newState := ""
+		if r != nil && r.State != nil {
+			newState = r.State.Name
+		}
+		changes = append(changes, Change{
+			Kind:      ChangeRequestState,
+			Project:   project,
+			RequestID: id,
+			Request:   r,
+			Old:       old.Requests[id],
+			New:       newState,
+		})
+	}
+
+	return state, changes, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedRequestIDs(m map[int]string) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
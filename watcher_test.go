@@ -0,0 +1,261 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	poll := func(results, infos, requests string) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/build/home:foo/_result", "multibuild=1"),
+				ghttp.RespondWith(http.StatusOK, results),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/source/home:foo", "view=info&nofilename=1"),
+				ghttp.RespondWith(http.StatusOK, infos),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/request", "view=collection&project=home%3Afoo&states=new%2Creview%2Cdeclined"),
+				ghttp.RespondWith(http.StatusOK, requests),
+			),
+		)
+	}
+
+	When("a project is polled twice", func() {
+		It("should report the changes since the first poll", func() {
+			poll(`
+				<resultlist state="c0ffee">
+					<result project="home:foo" repository="Debian_11" arch="x86_64" code="building" state="building">
+						<status package="bar" code="building"/>
+						<status package="baz" code="succeeded"/>
+					</result>
+				</resultlist>`, `
+				<sourceinfolist>
+					<sourceinfo package="bar" rev="5" srcmd5="ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8"/>
+					<sourceinfo package="baz" rev="2" srcmd5="0d5c3e3f16a1e1bde5b1ec6ec8b9d7f1"/>
+				</sourceinfolist>`, `
+				<collection matches="1">
+					<request id="1234" creator="quux">
+						<action type="submit">
+							<source project="home:quux" package="bar" rev="1"/>
+							<target project="home:foo" package="bar"/>
+						</action>
+						<state name="new"/>
+					</request>
+				</collection>`)
+			poll(`
+				<resultlist state="decaf">
+					<result project="home:foo" repository="Debian_11" arch="x86_64" code="published" state="published">
+						<status package="bar" code="failed"/>
+						<status package="baz" code="succeeded"/>
+					</result>
+				</resultlist>`, `
+				<sourceinfolist>
+					<sourceinfo package="bar" rev="5" srcmd5="ae4d2b5fa0d2b9e0ee07f0b1c6f0a3c8"/>
+					<sourceinfo package="baz" rev="3" srcmd5="4f3b3b7d1c8b3ee4b64d1f6c1e2a3b4c"/>
+				</sourceinfolist>`, `
+				<collection matches="1">
+					<request id="1240" creator="quux">
+						<action type="delete">
+							<target project="home:foo" package="baz"/>
+						</action>
+						<state name="review"/>
+					</request>
+				</collection>`)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/request/1234"),
+					ghttp.RespondWith(http.StatusOK, `
+						<request id="1234" creator="quux">
+							<action type="submit">
+								<source project="home:quux" package="bar" rev="1"/>
+								<target project="home:foo" package="bar"/>
+							</action>
+							<state name="accepted" who="foo"/>
+						</request>`),
+				),
+			)
+
+			var changes []Change
+			collect := func(change Change) error {
+				changes = append(changes, change)
+				return nil
+			}
+
+			w := NewWatcher(c, "home:foo")
+			err := w.Poll(collect)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+			Expect(w.State().Projects["home:foo"].Requests).To(Equal(map[int]string{1234: "new"}))
+
+			err = w.Poll(collect)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(HaveLen(4))
+			Expect(changes[0]).To(Equal(Change{
+				Kind:       ChangeBuildStatus,
+				Project:    "home:foo",
+				Package:    "bar",
+				Repository: "Debian_11",
+				Arch:       "x86_64",
+				Old:        "building",
+				New:        "failed",
+			}))
+			Expect(changes[1]).To(Equal(Change{
+				Kind:    ChangeCommit,
+				Project: "home:foo",
+				Package: "baz",
+				Old:     "2",
+				New:     "3",
+			}))
+			Expect(changes[2].Kind).To(Equal(ChangeNewRequest))
+			Expect(changes[2].RequestID).To(Equal(1240))
+			Expect(changes[2].Request.Actions[0].Type).To(Equal("delete"))
+			Expect(changes[2].New).To(Equal(RequestStateReview))
+			Expect(changes[3].Kind).To(Equal(ChangeRequestState))
+			Expect(changes[3].RequestID).To(Equal(1234))
+			Expect(changes[3].Old).To(Equal(RequestStateNew))
+			Expect(changes[3].New).To(Equal(RequestStateAccepted))
+		})
+	})
+
+	When("the handler fails", func() {
+		It("should report the changes again", func() {
+			scheduled := `
+				<resultlist state="decaf">
+					<result project="home:foo" repository="Debian_11" arch="x86_64" code="building" state="building">
+						<status package="bar" code="scheduled"/>
+					</result>
+				</resultlist>`
+			poll(`<resultlist state="c0ffee"/>`, `<sourceinfolist/>`, `<collection matches="0"/>`)
+			poll(scheduled, `<sourceinfolist/>`, `<collection matches="0"/>`)
+			poll(scheduled, `<sourceinfolist/>`, `<collection matches="0"/>`)
+
+			stateFile := filepath.Join(GinkgoT().TempDir(), "state.json")
+			w := NewWatcher(c, "home:foo")
+			w.StateFile = stateFile
+
+			// The first poll reports nothing, so the state is saved.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := w.Run(ctx, func(Change) error { return errors.New("unexpected") })
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(stateFile).To(BeAnExistingFile())
+
+			failure := errors.New("failure")
+			var seen []Change
+			err = w.Run(context.Background(), func(change Change) error {
+				seen = append(seen, change)
+				return failure
+			})
+			Expect(err).To(MatchError(failure))
+			Expect(seen).To(HaveLen(1))
+			Expect(seen[0].New).To(Equal("scheduled"))
+			Expect(w.State().Projects["home:foo"].Builds).To(BeEmpty())
+
+			// A watcher restarted from the saved state sees the change again.
+			w = NewWatcher(c, "home:foo")
+			w.StateFile = stateFile
+			Expect(w.LoadState()).To(Succeed())
+			seen = nil
+			err = w.Poll(func(change Change) error {
+				seen = append(seen, change)
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(seen).To(HaveLen(1))
+			Expect(w.State().Projects["home:foo"].Builds).To(HaveLen(1))
+		})
+	})
+
+	When("polling a project fails", func() {
+		It("should report the error and carry on with other projects", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/home:bar/_result"),
+					ghttp.RespondWith(http.StatusServiceUnavailable, `<status code="unavailable"><summary>Try again later</summary></status>`),
+				),
+			)
+			poll(`<resultlist state="c0ffee"/>`, `<sourceinfolist/>`, `<collection matches="0"/>`)
+
+			var failed []string
+			w := NewWatcher(c, "home:bar", "home:foo")
+			w.OnError = func(project string, err error) {
+				failed = append(failed, project)
+			}
+			err := w.Poll(func(Change) error { return nil })
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failed).To(Equal([]string{"home:bar"}))
+			Expect(w.State().Projects).To(HaveKey("home:foo"))
+			Expect(w.State().Projects).ToNot(HaveKey("home:bar"))
+		})
+	})
+
+	When("a watcher is created without NewWatcher", func() {
+		It("should work with the defaults", func() {
+			poll(`<resultlist state="c0ffee"/>`, `<sourceinfolist/>`, `
+				<collection matches="1">
+					<request id="42">
+						<action type="submit">
+							<source project="home:bar" package="bar"/>
+							<target project="home:foo" package="bar"/>
+						</action>
+						<state name="new" who="bar" when="2022-05-01T10:00:00"/>
+					</request>
+				</collection>`)
+			poll(`<resultlist state="c0ffee"/>`, `<sourceinfolist/>`, `<collection matches="0"/>`)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/request/42"),
+					ghttp.RespondWith(http.StatusNotFound, `<status code="not_found"><summary>Couldn't find Request with number '42'</summary></status>`),
+				),
+			)
+
+			w := &Watcher{Client: c, Projects: []string{"home:foo"}}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := w.Run(ctx, func(Change) error { return errors.New("unexpected") })
+			Expect(err).To(MatchError(context.Canceled))
+
+			var changes []Change
+			err = w.Poll(func(change Change) error {
+				changes = append(changes, change)
+				return nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes).To(Equal([]Change{{
+				Kind:      ChangeRequestState,
+				Project:   "home:foo",
+				RequestID: 42,
+				Old:       RequestStateNew,
+			}}))
+			Expect(w.State().Projects["home:foo"].Requests).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,105 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// Worker is a build worker. For building workers, the job being built
+// is described by the project, repository, package and architecture.
+type Worker struct {
+	WorkerID   string `xml:"workerid,attr"             json:"workerid"`
+	HostArch   string `xml:"hostarch,attr"             json:"hostarch"`
+	URI        string `xml:"uri,attr,omitempty"        json:"uri,omitempty"`
+	Project    string `xml:"project,attr,omitempty"    json:"project,omitempty"`
+	Repository string `xml:"repository,attr,omitempty" json:"repository,omitempty"`
+	Package    string `xml:"package,attr,omitempty"    json:"package,omitempty"`
+	Arch       string `xml:"arch,attr,omitempty"       json:"arch,omitempty"`
+	StartTime  int64  `xml:"starttime,attr,omitempty"  json:"starttime,omitempty"`
+	JobID      string `xml:"jobid,attr,omitempty"      json:"jobid,omitempty"`
+}
+
+// JobQueue is the number of jobs waiting for a worker (or blocked by
+// dependencies being built) for an architecture.
+type JobQueue struct {
+	Arch string `xml:"arch,attr" json:"arch"`
+	Jobs int    `xml:"jobs,attr" json:"jobs"`
+}
+
+// BuildAverage is the average build time for an architecture.
+type BuildAverage struct {
+	Arch     string `xml:"arch,attr"     json:"arch"`
+	BuildAvg int64  `xml:"buildavg,attr" json:"buildavg"`
+}
+
+// SchedulerQueue is the number of events waiting to be processed by
+// a scheduler, by priority.
+type SchedulerQueue struct {
+	High int `xml:"high,attr" json:"high"`
+	Med  int `xml:"med,attr"  json:"med"`
+	Low  int `xml:"low,attr"  json:"low"`
+	Next int `xml:"next,attr" json:"next"`
+}
+
+// Daemon is a backend service (scheduler, dispatcher, publisher etc).
+// Schedulers run per architecture and report their queue.
+type Daemon struct {
+	Type      string          `xml:"type,attr"           json:"type"`
+	Arch      string          `xml:"arch,attr,omitempty" json:"arch,omitempty"`
+	State     string          `xml:"state,attr"          json:"state"`
+	StartTime int64           `xml:"starttime,attr"      json:"starttime"`
+	Queue     *SchedulerQueue `xml:"queue,omitempty"     json:"queue,omitempty"`
+}
+
+// Partition is a set of backend daemons.
+type Partition struct {
+	Name    string   `xml:"name,attr,omitempty" json:"name,omitempty"`
+	Daemons []Daemon `xml:"daemon"              json:"daemons"`
+}
+
+// WorkerStatus describes the state of the build farm.
+type WorkerStatus struct {
+	XMLName    xml.Name       `xml:"workerstatus" json:"-"`
+	Clients    int            `xml:"clients,attr" json:"clients"`
+	Idle       []Worker       `xml:"idle"         json:"idle"`
+	Building   []Worker       `xml:"building"     json:"building"`
+	Away       []Worker       `xml:"away"         json:"away,omitempty"`
+	Down       []Worker       `xml:"down"         json:"down,omitempty"`
+	Dead       []Worker       `xml:"dead"         json:"dead,omitempty"`
+	Waiting    []JobQueue     `xml:"waiting"      json:"waiting"`
+	Blocked    []JobQueue     `xml:"blocked"      json:"blocked"`
+	BuildAvg   []BuildAverage `xml:"buildavg"     json:"buildavg,omitempty"`
+	Partitions []Partition    `xml:"partition"    json:"partitions"`
+}
+
+func (c *Client) getWorkerStatus(path string) (*WorkerStatus, error) {
+	req, err := c.NewRequest(http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status WorkerStatus
+	_, err = c.Do(req, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// GetWorkerStatus retrieves the state of the build workers and the
+// backend daemons. Older OBS versions which don’t provide /worker/_status
+// are queried at /build/_workerstatus instead.
+func (c *Client) GetWorkerStatus() (*WorkerStatus, error) {
+	status, err := c.getWorkerStatus("/worker/_status")
+	if isNotFound(err) {
+		return c.getWorkerStatus("/build/_workerstatus")
+	}
+
+	return status, err
+}
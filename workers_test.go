@@ -0,0 +1,95 @@
+// Copyright (C) 2022, Andrej Shadura
+// Copyright (C) 2022, Collabora Limited
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package obs
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+const workerStatusXML = `
+	<workerstatus clients="3">
+		<idle workerid="worker1:1" hostarch="x86_64" uri="http://10.0.0.2:4711"/>
+		<building workerid="worker1:2" hostarch="x86_64" uri="http://10.0.0.2:4712" project="home:foo" repository="Debian_11" package="bar" arch="x86_64" starttime="1651399200" jobid="c0ffee"/>
+		<dead workerid="worker2:1" hostarch="aarch64"/>
+		<waiting arch="x86_64" jobs="12"/>
+		<blocked arch="x86_64" jobs="3"/>
+		<buildavg arch="x86_64" buildavg="1200"/>
+		<partition>
+			<daemon type="srcserver" state="running" starttime="1651300000"/>
+			<daemon type="scheduler" arch="x86_64" state="running" starttime="1651300000">
+				<queue high="0" med="1" low="20" next="0"/>
+			</daemon>
+		</partition>
+	</workerstatus>`
+
+var _ = Describe("Worker status", func() {
+	var (
+		server *ghttp.Server
+		c      *Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		c, _ = NewClient(username, password, WithBaseURL(server.URL()))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	When("the worker status is requested", func() {
+		It("should return workers, queues and daemons", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/worker/_status"),
+					ghttp.VerifyBasicAuth(username, password),
+					ghttp.RespondWith(http.StatusOK, workerStatusXML),
+				),
+			)
+			status, err := c.GetWorkerStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Clients).To(Equal(3))
+			Expect(status.Idle).To(HaveLen(1))
+			Expect(status.Building).To(Equal([]Worker{{
+				WorkerID:   "worker1:2",
+				HostArch:   "x86_64",
+				URI:        "http://10.0.0.2:4712",
+				Project:    "home:foo",
+				Repository: "Debian_11",
+				Package:    "bar",
+				Arch:       "x86_64",
+				StartTime:  1651399200,
+				JobID:      "c0ffee",
+			}}))
+			Expect(status.Dead).To(HaveLen(1))
+			Expect(status.Waiting).To(Equal([]JobQueue{{"x86_64", 12}}))
+			Expect(status.Blocked).To(Equal([]JobQueue{{"x86_64", 3}}))
+			Expect(status.Partitions[0].Daemons[1].Queue).To(Equal(&SchedulerQueue{0, 1, 20, 0}))
+		})
+	})
+
+	When("the worker status is requested from an older OBS", func() {
+		It("should fall back to the older endpoint", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/worker/_status"),
+					ghttp.RespondWith(http.StatusNotFound, `<status code="not_found"><summary>no such route</summary></status>`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/build/_workerstatus"),
+					ghttp.RespondWith(http.StatusOK, workerStatusXML),
+				),
+			)
+			status, err := c.GetWorkerStatus()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Clients).To(Equal(3))
+		})
+	})
+})
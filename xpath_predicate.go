@@ -23,10 +23,15 @@ func XPathAttrEquals(name string, value string) *XPathPredicate {
 	}
 }
 
-func (p *XPathPredicate) String() string {
-	if strings.ContainsAny(p.value, "'") {
-		return p.path + p.operator + "\"" + p.value + "\""
+// xpathQuote quotes a string to be used as an XPath literal.
+func xpathQuote(value string) string {
+	if strings.ContainsAny(value, "'") {
+		return "\"" + value + "\""
 	}
 
-	return p.path + p.operator + "'" + p.value + "'"
+	return "'" + value + "'"
+}
+
+func (p *XPathPredicate) String() string {
+	return p.path + p.operator + xpathQuote(p.value)
 }